@@ -0,0 +1,162 @@
+package betterstack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// AlertMetadataValue represents a Better Stack alert metadata value, which may
+// be either a plain string or a JSON array of strings on the wire. The API
+// always returns arrays on reads but still accepts both shapes on writes.
+type AlertMetadataValue struct {
+	isArray bool
+	str     string
+	arr     []string
+}
+
+// AlertMetadataString returns a metadata value sent as a plain string.
+func AlertMetadataString(s string) AlertMetadataValue {
+	return AlertMetadataValue{str: s}
+}
+
+// AlertMetadataStrings returns a metadata value sent as an array of strings.
+func AlertMetadataStrings(arr []string) AlertMetadataValue {
+	return AlertMetadataValue{isArray: true, arr: arr}
+}
+
+// IsArray reports whether the value is an array of strings, see Strings.
+func (v AlertMetadataValue) IsArray() bool {
+	return v.isArray
+}
+
+// String returns the value of a plain string, or "" for an array.
+func (v AlertMetadataValue) String() string {
+	return v.str
+}
+
+// Strings returns the elements of an array, or nil for a plain string.
+func (v AlertMetadataValue) Strings() []string {
+	return v.arr
+}
+
+func (v *AlertMetadataValue) UnmarshalJSON(data []byte) error {
+	var arr []string
+	if err := json.Unmarshal(data, &arr); err == nil {
+		v.isArray = true
+		v.arr = arr
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		v.isArray = false
+		v.str = s
+		return nil
+	}
+	return fmt.Errorf("metadata value must be string or []string, got %s", string(data))
+}
+
+func (v AlertMetadataValue) MarshalJSON() ([]byte, error) {
+	if v.isArray {
+		if v.arr == nil {
+			return json.Marshal([]string{})
+		}
+		return json.Marshal(v.arr)
+	}
+	return json.Marshal(v.str)
+}
+
+// AlertEscalationTarget handles polymorphic response - can be null, string "current_team", or object
+type AlertEscalationTarget struct {
+	TeamID     *int    `json:"team_id,omitempty"`
+	TeamName   *string `json:"team_name,omitempty"`
+	PolicyID   *int    `json:"policy_id,omitempty"`
+	PolicyName *string `json:"policy_name,omitempty"`
+}
+
+// AlertEscalationTargetWrapper handles the polymorphic escalation_target field
+type AlertEscalationTargetWrapper struct {
+	Value *AlertEscalationTarget
+}
+
+func (w *AlertEscalationTargetWrapper) UnmarshalJSON(data []byte) error {
+	// Try to unmarshal as string first (e.g., "current_team")
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		// String value like "current_team" means no explicit target set
+		w.Value = nil
+		return nil
+	}
+
+	// Try to unmarshal as object
+	var target AlertEscalationTarget
+	if err := json.Unmarshal(data, &target); err != nil {
+		return err
+	}
+	w.Value = &target
+	return nil
+}
+
+func (w AlertEscalationTargetWrapper) MarshalJSON() ([]byte, error) {
+	if w.Value == nil {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(w.Value)
+}
+
+type Alert struct {
+	Name                     *string                       `json:"name,omitempty"`
+	AlertType                *string                       `json:"alert_type,omitempty"`
+	Operator                 *string                       `json:"operator,omitempty"`
+	Value                    *float64                      `json:"value,omitempty"`
+	StringValue              *string                       `json:"string_value,omitempty"`
+	QueryPeriod              *int                          `json:"query_period,omitempty"`
+	ConfirmationPeriod       *int                          `json:"confirmation_period,omitempty"`
+	RecoveryPeriod           *NullableInt                  `json:"recovery_period,omitempty"`
+	AggregationInterval      *int                          `json:"aggregation_interval,omitempty"`
+	CheckPeriod              *int                          `json:"check_period,omitempty"`
+	SeriesNames              *[]string                     `json:"series_names,omitempty"`
+	SeriesNamesExcept        *[]string                     `json:"series_names_except,omitempty"`
+	OnMissingData            *string                       `json:"on_missing_data,omitempty"`
+	SourceVariable           *string                       `json:"source_variable,omitempty"`
+	SourceMode               *string                       `json:"source_mode,omitempty"`
+	SourcePlatforms          []string                      `json:"source_platforms,omitempty"`
+	IncidentCause            *string                       `json:"incident_cause,omitempty"`
+	IncidentPerSeries        *bool                         `json:"incident_per_series,omitempty"`
+	Paused                   *bool                         `json:"paused,omitempty"`
+	PausedReason             *string                       `json:"paused_reason,omitempty"`
+	Call                     *bool                         `json:"call,omitempty"`
+	SMS                      *bool                         `json:"sms,omitempty"`
+	Email                    *bool                         `json:"email,omitempty"`
+	Push                     *bool                         `json:"push,omitempty"`
+	CriticalAlert            *bool                         `json:"critical_alert,omitempty"`
+	AnomalySensitivity       *float64                      `json:"anomaly_sensitivity,omitempty"`
+	AnomalyTrigger           *string                       `json:"anomaly_trigger,omitempty"`
+	AnomalyTrainingRangeDays *int                          `json:"anomaly_training_range_days,omitempty"`
+	AdditionalConditions     *[]AlertCondition             `json:"additional_conditions,omitempty"`
+	EscalationTarget         AlertEscalationTargetWrapper  `json:"escalation_target,omitempty"`
+	Metadata                 map[string]AlertMetadataValue `json:"metadata,omitempty"`
+	CreatedAt                *string                       `json:"created_at,omitempty"`
+	UpdatedAt                *string                       `json:"updated_at,omitempty"`
+}
+
+// AlertCondition is one additional Alert condition; the main condition lives
+// in the Alert's own alert_type/operator/value fields.
+type AlertCondition struct {
+	AlertType         *string   `json:"alert_type,omitempty"`
+	Operator          *string   `json:"operator,omitempty"`
+	Value             *float64  `json:"value,omitempty"`
+	StringValue       *string   `json:"string_value,omitempty"`
+	SeriesNames       *[]string `json:"series_names,omitempty"`
+	SeriesNamesExcept *[]string `json:"series_names_except,omitempty"`
+}
+
+// DashboardAlerts returns the alerts of a dashboard chart.
+func (c *Client) DashboardAlerts(dashboardID, chartID string) Endpoint[Alert] {
+	return newEndpoint[Alert](c, c.baseURL, "/api/v2/dashboards/"+url.PathEscape(dashboardID)+"/charts/"+url.PathEscape(chartID)+"/alerts")
+}
+
+// ExplorationAlerts returns the alerts of an exploration.
+func (c *Client) ExplorationAlerts(explorationID string) Endpoint[Alert] {
+	return newEndpoint[Alert](c, c.baseURL, "/api/v2/explorations/"+url.PathEscape(explorationID)+"/alerts")
+}
@@ -0,0 +1,48 @@
+package betterstack
+
+type ErrorsApplication struct {
+	Name                  *string             `json:"name,omitempty"`
+	Token                 *string             `json:"token,omitempty"`
+	JsTagToken            *string             `json:"js_tag_token,omitempty"`
+	TeamId                *StringOrInt        `json:"team_id,omitempty"`
+	TableName             *string             `json:"table_name,omitempty"`
+	Platform              *string             `json:"platform,omitempty"`
+	IngestingHost         *string             `json:"ingesting_host,omitempty"`
+	IngestingPaused       *bool               `json:"ingesting_paused,omitempty"`
+	ErrorsRetention       *int                `json:"errors_retention,omitempty"`
+	CreatedAt             *string             `json:"created_at,omitempty"`
+	UpdatedAt             *string             `json:"updated_at,omitempty"`
+	TeamName              *string             `json:"team_name,omitempty"`
+	DataRegion            *string             `json:"data_region,omitempty"`
+	CodeMappingStackRoot  *string             `json:"code_mapping_stack_root,omitempty"`
+	CodeMappingSourceRoot *string             `json:"code_mapping_source_root,omitempty"`
+	ApplicationGroupID    *int                `json:"application_group_id,omitempty"`
+	CorrelateWithSourceID *int                `json:"correlate_with_source_id,omitempty"`
+	GithubRepositoryName  *string             `json:"github_repository_name,omitempty"`
+	GitlabRepositoryName  *string             `json:"gitlab_repository_name,omitempty"`
+	CustomBucket          *SourceCustomBucket `json:"custom_bucket,omitempty"`
+
+	VrlTransformationExceptions *string `json:"vrl_transformation_exceptions,omitempty"`
+	VrlTransformationReplays    *string `json:"vrl_transformation_replays,omitempty"`
+	VrlTransformationWebEvents  *string `json:"vrl_transformation_web_events,omitempty"`
+	VrlTransformationLogs       *string `json:"vrl_transformation_logs,omitempty"`
+	VrlTransformationSpans      *string `json:"vrl_transformation_spans,omitempty"`
+}
+
+type ErrorsApplicationGroup struct {
+	Name      *string `json:"name,omitempty"`
+	CreatedAt *string `json:"created_at,omitempty"`
+	UpdatedAt *string `json:"updated_at,omitempty"`
+	TeamName  *string `json:"team_name,omitempty"`
+	SortIndex *int    `json:"sort_index,omitempty"`
+}
+
+// ErrorsApplications returns the applications of the errors API.
+func (c *Client) ErrorsApplications() Endpoint[ErrorsApplication] {
+	return newEndpoint[ErrorsApplication](c, c.errorsBaseURL, "/api/v2/applications")
+}
+
+// ErrorsApplicationGroups returns the application groups of the errors API.
+func (c *Client) ErrorsApplicationGroups() Endpoint[ErrorsApplicationGroup] {
+	return newEndpoint[ErrorsApplicationGroup](c, c.errorsBaseURL, "/api/v1/application-groups")
+}
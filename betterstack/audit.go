@@ -0,0 +1,104 @@
+package betterstack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ReadOnlyError is returned for requests other than GETs by a client with Config.ReadOnly set.
+// Nothing is sent to the API.
+type ReadOnlyError struct {
+	Method string
+	URL    string
+}
+
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("refusing to send %s %s: the client is read-only", e.Method, e.URL)
+}
+
+// AuditRecord is a line of the audit log, see Config.AuditLog.
+type AuditRecord struct {
+	Time   string `json:"time"`
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Resource and ResourceID are the Terraform resource type and ID the request was sent for,
+	// see WithResource. ResourceID is the ID of the created resource for a successful POST.
+	Resource   string `json:"resource,omitempty"`
+	ResourceID string `json:"resource_id,omitempty"`
+	// Status is the status code of the response, 0 if there's no response, see Error.
+	Status     int    `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+	RequestID  string `json:"request_id"`
+	// RequestBody is the request body, redacted like in the logs - even with Config.LogSensitive.
+	RequestBody json.RawMessage `json:"request_body,omitempty"`
+}
+
+type resourceContextKey struct{}
+
+type resourceContext struct {
+	typ string
+	id  string
+}
+
+// WithResource returns a context naming the resource the requests sent with it are for, recorded
+// in the audit log. id is empty while the resource is being created.
+func WithResource(ctx context.Context, typ, id string) context.Context {
+	return context.WithValue(ctx, resourceContextKey{}, resourceContext{typ: typ, id: id})
+}
+
+// audit appends a record of a request other than a GET to the audit log, if there is one.
+// resBody is nil when the request failed without a response.
+func (c *Client) audit(ctx context.Context, method, path string, start time.Time, res *http.Response, reqBody, resBody []byte, reqErr error) {
+	if c.auditLog == nil {
+		return
+	}
+	resource, _ := ctx.Value(resourceContextKey{}).(resourceContext)
+	record := AuditRecord{
+		Time:       start.UTC().Format(time.RFC3339Nano),
+		Method:     method,
+		Path:       path,
+		Resource:   resource.typ,
+		ResourceID: resource.id,
+		DurationMS: time.Since(start).Milliseconds(),
+		RequestID:  requestID(ctx),
+	}
+	if res != nil {
+		record.Status = res.StatusCode
+		if record.ResourceID == "" && res.StatusCode >= 200 && res.StatusCode < 300 {
+			var created struct {
+				Data struct {
+					ID json.RawMessage `json:"id"`
+				} `json:"data"`
+			}
+			if json.Unmarshal(resBody, &created) == nil && len(created.Data.ID) > 0 && string(created.Data.ID) != "null" {
+				// IDs are strings or numbers.
+				record.ResourceID = strings.Trim(string(created.Data.ID), `"`)
+			}
+		}
+	}
+	if reqErr != nil {
+		record.Error = reqErr.Error()
+	}
+	if len(reqBody) > 0 {
+		redacted := redactJSON(reqBody)
+		if !json.Valid(redacted) {
+			redacted, _ = json.Marshal(string(redacted))
+		}
+		record.RequestBody = redacted
+	}
+
+	line, err := json.Marshal(record)
+	if err == nil {
+		c.auditMu.Lock()
+		_, err = c.auditLog.Write(append(line, '\n'))
+		c.auditMu.Unlock()
+	}
+	if err != nil {
+		c.log(ctx, LogSubsystemAPI, LogWarn, "Writing the audit log failed", map[string]interface{}{"error": err.Error()})
+	}
+}
@@ -0,0 +1,115 @@
+package betterstack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAuditLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.RequestURI {
+		case "POST /api/v1/connections":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"data":{"id":"42","attributes":{"password":"secret"}}}`))
+		case "PATCH /api/v2/sources/1":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"errors":{"name":["can't be blank"]}}`))
+		case "DELETE /api/v2/sources/1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			_, _ = w.Write([]byte(`{"data":{"id":"1","attributes":{}}}`))
+		}
+	}))
+	defer server.Close()
+
+	var auditLog bytes.Buffer
+	c, err := New(Config{BaseURL: server.URL, Token: "foo", RetryMax: 0, AuditLog: &auditLog})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	clientType := "clickhouse"
+	if _, err := c.Connections().Create(WithResource(ctx, "logtail_connection", ""), &Connection{ClientType: &clientType, IpAllowlist: &[]string{"10.0.0.1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Sources().Get(ctx, "1"); err != nil {
+		t.Fatal(err)
+	}
+	ctx = WithResource(ctx, "logtail_source", "1")
+	name, token := "", "t0ken"
+	if err := c.Sources().Update(ctx, "1", &Source{Name: &name, Token: &token}); err == nil {
+		t.Error("expected the update to fail")
+	}
+	if err := c.Sources().Delete(ctx, "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	var records []AuditRecord
+	for _, line := range strings.Split(strings.TrimSpace(auditLog.String()), "\n") {
+		var record AuditRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid record %s: %v", line, err)
+		}
+		if record.Time == "" || record.RequestID == "" {
+			t.Errorf("got record without time or request_id: %s", line)
+		}
+		records = append(records, record)
+	}
+
+	want := []struct {
+		method, path, resource, resourceID, body string
+		status                                   int
+	}{
+		// The ID of a created resource is taken from the response.
+		{"POST", "/api/v1/connections", "logtail_connection", "42", `{"client_type":"clickhouse","ip_allowlist":["10.0.0.1"]}`, 201},
+		{"PATCH", "/api/v2/sources/1", "logtail_source", "1", `{"name":"","token":"***"}`, 422},
+		{"DELETE", "/api/v2/sources/1", "logtail_source", "1", "", 204},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d:\n%s", len(records), len(want), auditLog.String())
+	}
+	for i, w := range want {
+		r := records[i]
+		if r.Method != w.method || r.Path != w.path || r.Resource != w.resource || r.ResourceID != w.resourceID || string(r.RequestBody) != w.body || r.Status != w.status {
+			t.Errorf("got record %+v (body %s), want %+v", r, r.RequestBody, w)
+		}
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.RequestURI)
+		_, _ = w.Write([]byte(`{"data":{"id":"1","attributes":{}}}`))
+	}))
+	defer server.Close()
+
+	c, err := New(Config{BaseURL: server.URL, Token: "foo", RetryMax: 0, ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if _, err := c.Sources().Get(ctx, "1"); err != nil {
+		t.Fatal(err)
+	}
+	name := "a"
+	_, err = c.Sources().Create(ctx, &Source{Name: &name})
+	var readOnlyErr *ReadOnlyError
+	if !errors.As(err, &readOnlyErr) || readOnlyErr.Method != http.MethodPost || readOnlyErr.URL != server.URL+"/api/v2/sources" {
+		t.Errorf("got error %v, want a ReadOnlyError", err)
+	}
+	if err := c.Sources().Delete(ctx, "1"); !errors.As(err, &readOnlyErr) {
+		t.Errorf("got error %v, want a ReadOnlyError", err)
+	}
+	if got := strings.Join(requests, ","); got != "GET /api/v2/sources/1" {
+		t.Errorf("got requests %s, want only the GET", got)
+	}
+}
@@ -0,0 +1,89 @@
+package betterstack
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// responseCache memoizes successful GET responses and coalesces identical concurrent GETs, so
+// lookups listing the same collection or reading the same resource share one request. Any other
+// request may change what a GET returns, so it empties the cache, see invalidate.
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	// generation changes whenever the cache is invalidated. Responses to GETs sent before are
+	// still returned to the requests waiting for them, but not kept.
+	generation uint64
+}
+
+// cacheEntry is a GET response, in flight until done is closed.
+type cacheEntry struct {
+	done       chan struct{}
+	generation uint64
+	res        *http.Response
+	body       []byte
+	err        error
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{entries: make(map[string]*cacheEntry)}
+}
+
+// get returns the cached response to the GET of key, waiting for the identical request in flight
+// if there is one, and reports whether it was shared. Otherwise it sends the request with fetch.
+func (rc *responseCache) get(ctx context.Context, key string, fetch func() (*http.Response, error)) (*http.Response, bool, error) {
+	rc.mu.Lock()
+	if e, ok := rc.entries[key]; ok {
+		rc.mu.Unlock()
+		select {
+		case <-e.done:
+			res, err := e.response()
+			return res, true, err
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	}
+	e := &cacheEntry{done: make(chan struct{}), generation: rc.generation}
+	rc.entries[key] = e
+	rc.mu.Unlock()
+
+	e.res, e.err = fetch()
+	if e.err == nil {
+		e.body, e.err = io.ReadAll(e.res.Body)
+		_ = e.res.Body.Close()
+	}
+
+	rc.mu.Lock()
+	// Errors aren't cached, nor are responses that may predate a change.
+	if e.err != nil || e.res.StatusCode != http.StatusOK || e.generation != rc.generation {
+		if rc.entries[key] == e {
+			delete(rc.entries, key)
+		}
+	}
+	rc.mu.Unlock()
+	close(e.done)
+	res, err := e.response()
+	return res, false, err
+}
+
+// invalidate empties the cache, called before and after every request other than a GET.
+func (rc *responseCache) invalidate() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.generation++
+	rc.entries = make(map[string]*cacheEntry)
+}
+
+// response returns a copy of the response with its own body, as every caller closes it.
+func (e *cacheEntry) response() (*http.Response, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	res := *e.res
+	res.Header = e.res.Header.Clone()
+	res.Body = io.NopCloser(bytes.NewReader(e.body))
+	return &res, nil
+}
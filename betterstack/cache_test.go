@@ -0,0 +1,154 @@
+package betterstack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	name := "a"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.RequestURI)
+		switch r.Method + " " + r.RequestURI {
+		case "GET /api/v2/sources?page=1":
+			_, _ = w.Write([]byte(`{"data":[{"id":"1","attributes":{"name":"` + name + `"}}],"pagination":{"next":"/api/v2/sources?page=2"}}`))
+		case "GET /api/v2/sources?page=2":
+			_, _ = w.Write([]byte(`{"data":[{"id":"2","attributes":{"name":"b"}}],"pagination":{"next":null}}`))
+		case "PATCH /api/v2/sources/1":
+			name = "c"
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		cache        bool
+		wantNames    string
+		wantRequests string
+	}{
+		{
+			name:      "enabled",
+			cache:     true,
+			wantNames: "a,b|a,b|c,b",
+			// Pages are requested again after the PATCH, the 404 is never cached.
+			wantRequests: "GET /api/v2/sources?page=1,GET /api/v2/sources?page=2,GET /api/v2/sources/9,GET /api/v2/sources/9," +
+				"PATCH /api/v2/sources/1,GET /api/v2/sources?page=1,GET /api/v2/sources?page=2",
+		},
+		{
+			name:      "disabled",
+			cache:     false,
+			wantNames: "a,b|a,b|c,b",
+			wantRequests: "GET /api/v2/sources?page=1,GET /api/v2/sources?page=2,GET /api/v2/sources/9,GET /api/v2/sources?page=1,GET /api/v2/sources?page=2,GET /api/v2/sources/9," +
+				"PATCH /api/v2/sources/1,GET /api/v2/sources?page=1,GET /api/v2/sources?page=2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests, name = nil, "a"
+			c, err := New(Config{BaseURL: server.URL, Token: "foo", RetryMax: 0, Cache: tt.cache})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.Background()
+
+			var lists []string
+			list := func() {
+				var names []string
+				for item, err := range c.Sources().List(ctx) {
+					if err != nil {
+						t.Fatal(err)
+					}
+					names = append(names, *item.Attributes.Name)
+				}
+				lists = append(lists, strings.Join(names, ","))
+			}
+			list()
+			if _, err := c.Sources().Get(ctx, "9"); !IsNotFound(err) {
+				t.Errorf("got error %v, want not found", err)
+			}
+			list()
+			if _, err := c.Sources().Get(ctx, "9"); !IsNotFound(err) {
+				t.Errorf("got error %v, want not found", err)
+			}
+			newName := "c"
+			if err := c.Sources().Update(ctx, "1", &Source{Name: &newName}); err != nil {
+				t.Fatal(err)
+			}
+			list()
+
+			if got := strings.Join(lists, "|"); got != tt.wantNames {
+				t.Errorf("got names %s, want %s", got, tt.wantNames)
+			}
+			if got := strings.Join(requests, ","); got != tt.wantRequests {
+				t.Errorf("got requests\n%s\nwant\n%s", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestCacheCoalescesConcurrentRequests(t *testing.T) {
+	var mu sync.Mutex
+	var requests int
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		<-release
+		_, _ = w.Write([]byte(`{"data":{"id":"1","attributes":{"name":"a"}}}`))
+	}))
+	defer server.Close()
+
+	c, err := New(Config{BaseURL: server.URL, Token: "foo", RetryMax: 0, Cache: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			item, err := c.Sources().Get(context.Background(), "1")
+			if err == nil && *item.Attributes.Name != "a" {
+				t.Errorf("got name %q", *item.Attributes.Name)
+			}
+			errs <- err
+		}()
+	}
+	// Wait for the first request to arrive, the others wait for its response.
+	for {
+		mu.Lock()
+		arrived := requests
+		mu.Unlock()
+		if arrived > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, want 1", requests)
+	}
+}
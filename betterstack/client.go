@@ -0,0 +1,330 @@
+// Package betterstack is a typed client for the Better Stack Telemetry and Errors APIs. It's the
+// client the Terraform provider uses, so tooling built on it sends the same requests.
+//
+//	c, err := betterstack.New(betterstack.Config{Token: os.Getenv("LOGTAIL_API_TOKEN")})
+//	for item, err := range c.Sources().List(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(item.ID, *item.Attributes.Name)
+//	}
+package betterstack
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"golang.org/x/time/rate"
+)
+
+const (
+	DefaultBaseURL       = "https://telemetry.betterstack.com"
+	DefaultErrorsBaseURL = "https://errors.betterstack.com"
+)
+
+func rateLimitRetryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if err != nil {
+		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	}
+
+	if resp.StatusCode == 429 {
+		return true, nil
+	}
+
+	return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+}
+
+type Client struct {
+	baseURL       string
+	errorsBaseURL string
+	token         string
+	errorsToken   string
+	retryClient   *retryablehttp.Client
+	userAgent     string
+	rateLimiter   *rate.Limiter
+	// maxRateLimit and maxRateBurst are the configured limits, the rate limiter is adapted below
+	// them when the API reports that few requests are left, see adaptRateLimit.
+	maxRateLimit rate.Limit
+	maxRateBurst int
+	logger       Logger
+	logSensitive bool
+	// cache is nil unless Config.Cache is set.
+	cache    *responseCache
+	readOnly bool
+	auditLog io.Writer
+	auditMu  sync.Mutex
+}
+
+type Config struct {
+	// BaseURL is the base URL of the Telemetry API, defaults to https://telemetry.betterstack.com.
+	BaseURL string
+	// ErrorsBaseURL is the base URL of the errors API. Defaults to https://errors.betterstack.com
+	// for the production BaseURL and to BaseURL otherwise, so a single test server receives both.
+	ErrorsBaseURL string
+	Token         string
+	// ErrorsToken authenticates the requests sent to ErrorsBaseURL, when the Errors API token is
+	// scoped differently. Defaults to Token, and to Token too while both APIs share a base URL.
+	ErrorsToken  string
+	UserAgent    string
+	HTTPClient   *http.Client
+	RetryMax     int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	// RateLimit is the maximum of requests per second, 0 = no limit. The client goes slower when
+	// the X-RateLimit-Remaining response header shows the API rate limit is running out.
+	RateLimit int
+	RateBurst int // burst size for rate limiter, 0 = use default
+	// Logger receives a log of every request and response, see LogSubsystemAPI. Nil disables
+	// logging.
+	Logger Logger
+	// LogSensitive logs request and response bodies without redacting tokens, passwords and other
+	// secrets. Only meant for debugging the client itself.
+	LogSensitive bool
+	// Cache keeps successful GET responses for the lifetime of the client and sends identical
+	// concurrent GETs only once. Any POST, PATCH or DELETE empties the cache, so the client's own
+	// changes are always read back, but changes made elsewhere aren't seen until then.
+	Cache bool
+	// ReadOnly refuses to send requests other than GETs, returning a ReadOnlyError instead.
+	ReadOnly bool
+	// AuditLog receives a JSON line for every request other than a GET, see AuditRecord.
+	AuditLog io.Writer
+}
+
+func New(config Config) (*Client, error) {
+	if config.BaseURL == "" {
+		config.BaseURL = DefaultBaseURL
+	}
+	// Set reasonable bounds for max retries
+	if config.RetryMax < 0 || config.RetryMax > 10 {
+		config.RetryMax = 10
+	}
+	// Set default wait times
+	if config.RetryWaitMin == 0 {
+		config.RetryWaitMin = 1 * time.Second
+	}
+	if config.RetryWaitMax == 0 {
+		config.RetryWaitMax = 30 * time.Second
+	}
+
+	// Create retry client
+	retryClient := retryablehttp.NewClient()
+	retryClient.RetryMax = config.RetryMax
+	retryClient.RetryWaitMin = config.RetryWaitMin
+	retryClient.RetryWaitMax = config.RetryWaitMax
+	retryClient.Backoff = backoff
+
+	// Use custom HTTP client if provided
+	if config.HTTPClient != nil {
+		retryClient.HTTPClient = config.HTTPClient
+	}
+
+	// Retries are logged by logRetry, with the request_id of the request.
+	retryClient.Logger = nil
+
+	// Create rate limiter, an unlimited one is still adapted to the API rate limit
+	maxRateLimit, maxRateBurst := rate.Inf, 0
+	if config.RateLimit > 0 {
+		burst := config.RateBurst
+		if burst <= 0 {
+			// Default burst: allow accumulating up to 2 seconds worth of requests
+			// This handles Terraform's pattern of idle-then-busy well
+			burst = config.RateLimit * 2
+			if burst < 10 {
+				burst = 10 // Minimum burst of 10 for reasonable performance
+			}
+		}
+		maxRateLimit, maxRateBurst = rate.Limit(config.RateLimit), burst
+	}
+	rateLimiter := rate.NewLimiter(maxRateLimit, maxRateBurst)
+
+	errorsBaseURL := config.ErrorsBaseURL
+	if errorsBaseURL == "" {
+		errorsBaseURL = DefaultErrorsBaseURL
+		// Override with test URL if baseURL is not the production URL
+		if config.BaseURL != DefaultBaseURL {
+			errorsBaseURL = config.BaseURL
+		}
+	}
+
+	c := &Client{
+		baseURL:       config.BaseURL,
+		errorsBaseURL: errorsBaseURL,
+		token:         config.Token,
+		errorsToken:   config.ErrorsToken,
+		retryClient:   retryClient,
+		userAgent:     config.UserAgent,
+		rateLimiter:   rateLimiter,
+		maxRateLimit:  maxRateLimit,
+		maxRateBurst:  maxRateBurst,
+		logger:        config.Logger,
+		logSensitive:  config.LogSensitive,
+		readOnly:      config.ReadOnly,
+		auditLog:      config.AuditLog,
+	}
+	if config.Cache {
+		c.cache = newResponseCache()
+	}
+	retryClient.CheckRetry = c.checkRetry
+	retryClient.RequestLogHook = c.logRetry
+	return c, nil
+}
+
+func (c *Client) Get(ctx context.Context, path string) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, c.baseURL, path, nil)
+}
+
+func (c *Client) Post(ctx context.Context, path string, body io.Reader) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, c.baseURL, path, body)
+}
+
+func (c *Client) Patch(ctx context.Context, path string, body io.Reader) (*http.Response, error) {
+	return c.do(ctx, http.MethodPatch, c.baseURL, path, body)
+}
+
+func (c *Client) Delete(ctx context.Context, path string) (*http.Response, error) {
+	return c.do(ctx, http.MethodDelete, c.baseURL, path, nil)
+}
+
+func (c *Client) GetWithBaseURL(ctx context.Context, baseURL, path string) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, baseURL, path, nil)
+}
+
+func (c *Client) PostWithBaseURL(ctx context.Context, baseURL, path string, body io.Reader) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, baseURL, path, body)
+}
+
+func (c *Client) PatchWithBaseURL(ctx context.Context, baseURL, path string, body io.Reader) (*http.Response, error) {
+	return c.do(ctx, http.MethodPatch, baseURL, path, body)
+}
+
+func (c *Client) DeleteWithBaseURL(ctx context.Context, baseURL, path string) (*http.Response, error) {
+	return c.do(ctx, http.MethodDelete, baseURL, path, nil)
+}
+
+func (c *Client) TelemetryBaseURL() string {
+	return c.baseURL
+}
+
+func (c *Client) ErrorsBaseURL() string {
+	return c.errorsBaseURL
+}
+
+// tokenFor returns the token for requests to baseURL.
+func (c *Client) tokenFor(baseURL string) string {
+	if c.errorsToken != "" && baseURL == c.errorsBaseURL && baseURL != c.baseURL {
+		return c.errorsToken
+	}
+	return c.token
+}
+
+func (c *Client) do(ctx context.Context, method, baseURL, path string, body io.Reader) (*http.Response, error) {
+	if c.readOnly && method != http.MethodGet {
+		return nil, &ReadOnlyError{Method: method, URL: baseURL + path}
+	}
+	if c.cache == nil {
+		return c.send(ctx, method, baseURL, path, body)
+	}
+	if method != http.MethodGet {
+		// Invalidated after the request too, a GET sent meanwhile may have seen the old state.
+		c.cache.invalidate()
+		defer c.cache.invalidate()
+		return c.send(ctx, method, baseURL, path, body)
+	}
+	res, shared, err := c.cache.get(ctx, baseURL+path, func() (*http.Response, error) {
+		return c.send(ctx, method, baseURL, path, nil)
+	})
+	if shared && err == nil {
+		c.log(ctx, LogSubsystemAPI, LogDebug, "Using cached API response", map[string]interface{}{
+			"method":      method,
+			"url":         baseURL + path,
+			"status_code": res.StatusCode,
+		})
+	}
+	return res, err
+}
+
+// send sends a request, retrying it as configured, and logs it.
+func (c *Client) send(ctx context.Context, method, baseURL, path string, body io.Reader) (*http.Response, error) {
+	ctx = context.WithValue(ctx, requestIDContextKey{}, newRequestID())
+
+	// Apply rate limiting
+	if err := c.waitRateLimit(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter: %w", err)
+	}
+
+	// The body is read upfront to be logged, retryablehttp buffers it for retries anyway.
+	var reqBody []byte
+	var rawBody interface{}
+	if body != nil {
+		var err error
+		if reqBody, err = io.ReadAll(body); err != nil {
+			return nil, err
+		}
+		rawBody = reqBody
+	}
+	req, err := retryablehttp.NewRequest(method, fmt.Sprintf("%s%s", baseURL, path), rawBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.tokenFor(baseURL)))
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if method == http.MethodPost || method == http.MethodPatch {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if key := idempotencyKey(ctx); key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+
+	fields := map[string]interface{}{
+		"method": method,
+		"url":    req.URL.String(),
+	}
+	if reqBody != nil {
+		fields["request_body"] = c.logBody(reqBody)
+	}
+	c.log(ctx, LogSubsystemAPI, LogDebug, "Sending API request", fields)
+
+	start := time.Now()
+	res, err := c.retryClient.Do(req.WithContext(ctx))
+	fields = map[string]interface{}{
+		"method":      method,
+		"url":         req.URL.String(),
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	audited := c.auditLog != nil && method != http.MethodGet
+	if err != nil {
+		fields["error"] = err.Error()
+		c.log(ctx, LogSubsystemAPI, LogWarn, "API request failed", fields)
+		if audited {
+			c.audit(ctx, method, path, start, nil, reqBody, nil, err)
+		}
+		return nil, sentRequestError(ctx, err)
+	}
+	if c.logger != nil || audited {
+		// Buffer the body to log it, API responses are small JSON documents.
+		resBody, err := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		if err != nil {
+			return nil, sentRequestError(ctx, err)
+		}
+		res.Body = io.NopCloser(bytes.NewReader(resBody))
+		fields["status_code"] = res.StatusCode
+		fields["response_body"] = c.logBody(resBody)
+		if id := res.Header.Get("X-Request-Id"); id != "" {
+			fields["api_request_id"] = id
+		}
+		c.log(ctx, LogSubsystemAPI, LogDebug, "Received API response", fields)
+		if audited {
+			c.audit(ctx, method, path, start, res, reqBody, resBody, nil)
+		}
+	}
+	return res, nil
+}
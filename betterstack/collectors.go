@@ -0,0 +1,119 @@
+package betterstack
+
+import "net/url"
+
+// CollectorComponents maps the flat API component names to their JSON keys.
+type CollectorComponents struct {
+	LogsHost              *bool `json:"logs_host,omitempty"`
+	LogsDocker            *bool `json:"logs_docker,omitempty"`
+	LogsKubernetes        *bool `json:"logs_kubernetes,omitempty"`
+	LogsCollectorInternal *bool `json:"logs_collector_internals,omitempty"`
+	MetricsDatabases      *bool `json:"metrics_databases,omitempty"`
+	MetricsNginx          *bool `json:"metrics_nginx,omitempty"`
+	MetricsApache         *bool `json:"metrics_apache,omitempty"`
+	MetricsTraefik        *bool `json:"metrics_traefik,omitempty"`
+	EbpfMetrics           *bool `json:"ebpf_metrics,omitempty"`
+	EbpfTracingBasic      *bool `json:"ebpf_tracing_basic,omitempty"`
+	EbpfTracingFull       *bool `json:"ebpf_tracing_full,omitempty"`
+	TracesOpentelemetry   *bool `json:"traces_opentelemetry,omitempty"`
+	EbpfRedMetrics        *bool `json:"ebpf_red_metrics,omitempty"`
+}
+
+type CollectorEntityOption struct {
+	LogSampling  *int  `json:"log_sampling,omitempty"`
+	IngestTraces *bool `json:"ingest_traces,omitempty"`
+}
+
+type CollectorConfiguration struct {
+	LogsSampleRate       *int                             `json:"logs_sample_rate,omitempty"`
+	TracesSampleRate     *int                             `json:"traces_sample_rate,omitempty"`
+	Components           *CollectorComponents             `json:"components,omitempty"`
+	VRLTransformation    *string                          `json:"vrl_transformation,omitempty"`
+	MergeLogs            *bool                            `json:"merge_logs,omitempty"`
+	MergeLogsConfig      *string                          `json:"merge_logs_config,omitempty"`
+	DiskBatchSizeMB      *int                             `json:"disk_batch_size_mb,omitempty"`
+	MemoryBatchSizeMB    *int                             `json:"memory_batch_size_mb,omitempty"`
+	BufferMaxEvents      *int                             `json:"buffer_max_events,omitempty"`
+	WhenFull             *string                          `json:"when_full,omitempty"`
+	LogLineLengthLimitKB *int                             `json:"log_line_length_limit_kb,omitempty"`
+	ServicesOptions      map[string]CollectorEntityOption `json:"services_options,omitempty"`
+	NamespacesOptions    map[string]CollectorEntityOption `json:"namespaces_options,omitempty"`
+}
+
+type CollectorCustomBucket struct {
+	Name                   *string `json:"name,omitempty"`
+	Endpoint               *string `json:"endpoint,omitempty"`
+	AccessKeyID            *string `json:"access_key_id,omitempty"`
+	SecretAccessKey        *string `json:"secret_access_key,omitempty"`
+	KeepDataAfterRetention *bool   `json:"keep_data_after_retention,omitempty"`
+}
+
+type CollectorDatabase struct {
+	ID          *int    `json:"id,omitempty"`
+	ServiceType *string `json:"service_type,omitempty"`
+	Host        *string `json:"host,omitempty"`
+	Port        *int    `json:"port,omitempty"`
+	Username    *string `json:"username,omitempty"`
+	Password    *string `json:"password,omitempty"`
+	SSLMode     *string `json:"ssl_mode,omitempty"`
+	TLS         *string `json:"tls,omitempty"`
+	Destroy     *bool   `json:"_destroy,omitempty"`
+}
+
+type Collector struct {
+	Name                    *string                 `json:"name,omitempty"`
+	Platform                *string                 `json:"platform,omitempty"`
+	Note                    *string                 `json:"note,omitempty"`
+	Status                  *string                 `json:"status,omitempty"`
+	Secret                  *string                 `json:"secret,omitempty"`
+	SourceID                *int                    `json:"source_id,omitempty"`
+	SourceGroupID           *int                    `json:"source_group_id,omitempty"`
+	LiveTailPattern         *string                 `json:"live_tail_pattern,omitempty"`
+	DataRegion              *string                 `json:"data_region,omitempty"`
+	TeamID                  *StringOrInt            `json:"team_id,omitempty"`
+	TeamName                *string                 `json:"team_name,omitempty"`
+	LogsRetention           *int                    `json:"logs_retention,omitempty"`
+	MetricsRetention        *int                    `json:"metrics_retention,omitempty"`
+	IngestingPaused         *bool                   `json:"ingesting_paused,omitempty"`
+	HostsCount              *int                    `json:"hosts_count,omitempty"`
+	HostsUpCount            *int                    `json:"hosts_up_count,omitempty"`
+	DatabasesCount          *int                    `json:"databases_count,omitempty"`
+	PingedAt                *string                 `json:"pinged_at,omitempty"`
+	CreatedAt               *string                 `json:"created_at,omitempty"`
+	UpdatedAt               *string                 `json:"updated_at,omitempty"`
+	UserVectorConfig        *string                 `json:"user_vector_config,omitempty"`
+	SourceVrlTransformation *string                 `json:"source_vrl_transformation,omitempty"`
+	Configuration           *CollectorConfiguration `json:"configuration,omitempty"`
+	CustomBucket            *CollectorCustomBucket  `json:"custom_bucket,omitempty"`
+	Databases               *[]CollectorDatabase    `json:"databases,omitempty"`
+}
+
+type CollectorTarget struct {
+	Kind      *string `json:"kind,omitempty"`
+	Host      *string `json:"host,omitempty"`
+	Port      *int    `json:"port,omitempty"`
+	Service   *string `json:"service,omitempty"`
+	ListenIP  *string `json:"listen_ip,omitempty"`
+	Endpoint  *string `json:"endpoint,omitempty"`
+	Scheme    *string `json:"scheme,omitempty"`
+	Username  *string `json:"username,omitempty"`
+	Password  *string `json:"password,omitempty"`
+	APIKey    *string `json:"api_key,omitempty"`
+	SSLMode   *string `json:"ssl_mode,omitempty"`
+	TLS       *string `json:"tls,omitempty"`
+	Enabled   *bool   `json:"enabled,omitempty"`
+	Status    *string `json:"status,omitempty"`
+	Container *string `json:"container,omitempty"`
+	CreatedAt *string `json:"created_at,omitempty"`
+	UpdatedAt *string `json:"updated_at,omitempty"`
+}
+
+// Collectors returns the Better Stack collectors.
+func (c *Client) Collectors() Endpoint[Collector] {
+	return newEndpoint[Collector](c, c.baseURL, "/api/v1/collectors")
+}
+
+// CollectorTargets returns the targets, e.g. databases, monitored by a collector.
+func (c *Client) CollectorTargets(collectorID string) Endpoint[CollectorTarget] {
+	return newEndpoint[CollectorTarget](c, c.baseURL, "/api/v1/collectors/"+url.PathEscape(collectorID)+"/targets")
+}
@@ -0,0 +1,24 @@
+package betterstack
+
+type Connection struct {
+	ClientType  *string                   `json:"client_type,omitempty"`
+	TeamNames   *[]string                 `json:"team_names,omitempty"`
+	TeamIds     *[]int                    `json:"team_ids,omitempty"`
+	DataRegion  *string                   `json:"data_region,omitempty"`
+	IpAllowlist *[]string                 `json:"ip_allowlist,omitempty"`
+	ValidUntil  *string                   `json:"valid_until,omitempty"`
+	Note        *string                   `json:"note,omitempty"`
+	Host        *string                   `json:"host,omitempty"`
+	Port        *int                      `json:"port,omitempty"`
+	Username    *string                   `json:"username,omitempty"`
+	Password    *string                   `json:"password,omitempty"`
+	CreatedAt   *string                   `json:"created_at,omitempty"`
+	CreatedBy   map[string]interface{}    `json:"created_by,omitempty"`
+	SampleQuery *string                   `json:"sample_query,omitempty"`
+	DataSources *[]map[string]interface{} `json:"data_sources,omitempty"`
+}
+
+// Connections returns the ClickHouse connections for querying Telemetry data.
+func (c *Client) Connections() Endpoint[Connection] {
+	return newEndpoint[Connection](c, c.baseURL, "/api/v1/connections")
+}
@@ -0,0 +1,289 @@
+package betterstack
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Retrying a POST whose response was lost (a timeout, a dropped connection or a 5xx from a proxy)
+// can create the resource twice. Create doesn't leave those retries to the retry client: it first
+// lists the collection and adopts a resource matching the request created since the first attempt.
+// All attempts of a create also send the same Idempotency-Key header for the API to recognize them.
+//
+// Creates whose request can't be matched against the listed resources are protected by the
+// Idempotency-Key header only:
+//   - requests choosing the team by team_name, including those filled in from default_team_name,
+//     as resources are listed with their team_id only;
+//   - requests without the create keys, e.g. collector targets and connections, which have no name.
+//
+// Creates that mustn't be repeated at all, e.g. source token rotations, use WithoutCreateRetry.
+
+// createdAtTolerance allows for the clock of the API and the local one to differ when matching
+// created_at against the start of a create.
+const createdAtTolerance = time.Minute
+
+type createContextKey struct{}
+
+// isCreate reports whether ctx is the context of a request sent by Create.
+func isCreate(ctx context.Context) bool {
+	return ctx.Value(createContextKey{}) != nil
+}
+
+// idempotencyKey returns the Idempotency-Key of the create ctx is the context of, if any.
+func idempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(createContextKey{}).(string)
+	return key
+}
+
+type createKeysContextKey struct{}
+
+// WithCreateKeys returns a context whose Create adopts a resource it may have created only when
+// the attributes keys of the resource match the request, e.g. "region" and "name" for resources
+// whose names are unique per region. By default, resources are matched by "name" alone. The parent
+// of a resource is matched by the collection path, its team by the team_id of the request.
+func WithCreateKeys(ctx context.Context, keys ...string) context.Context {
+	return context.WithValue(ctx, createKeysContextKey{}, keys)
+}
+
+func createKeys(ctx context.Context) []string {
+	if keys, ok := ctx.Value(createKeysContextKey{}).([]string); ok {
+		return keys
+	}
+	return []string{"name"}
+}
+
+type createListPathContextKey struct{}
+
+// WithCreateListPath returns a context whose Create looks for a resource it may have created in the
+// collection at path instead of the path it POSTs to, e.g. for /api/v2/dashboards/import.
+func WithCreateListPath(ctx context.Context, path string) context.Context {
+	return context.WithValue(ctx, createListPathContextKey{}, path)
+}
+
+func createListPath(ctx context.Context, path string) string {
+	if listPath, ok := ctx.Value(createListPathContextKey{}).(string); ok {
+		return listPath
+	}
+	return path
+}
+
+type noCreateRetryContextKey struct{}
+
+// WithoutCreateRetry returns a context whose Create neither retries nor adopts a resource when it's
+// unknown whether the POST created it, for creates whose repetition does harm, e.g. a rotation.
+// Use MayHaveCreated to tell these failures apart.
+func WithoutCreateRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCreateRetryContextKey{}, true)
+}
+
+// MayHaveCreated reports whether err is the failure of a Create that may still have created the
+// resource.
+func MayHaveCreated(err error) bool {
+	return isAmbiguousCreateError(err)
+}
+
+// ambiguousCreateFailure reports whether a POST that failed with resp and err may still have
+// created the resource. Connection errors before the request was sent and responses rejecting
+// the request as a whole, 429 and 503, are safe to retry.
+func ambiguousCreateFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		var opErr *net.OpError
+		return !errors.As(err, &opErr) || opErr.Op != "dial"
+	}
+	return resp.StatusCode >= 500 && resp.StatusCode != http.StatusServiceUnavailable
+}
+
+// Create POSTs in as JSON to baseURL+path and decodes the 201 Created response into out.
+//
+// When the request was sent but it's unknown whether it created the resource, Create lists the
+// collection at baseURL+path, see WithCreateListPath, before retrying. A resource created since the
+// first attempt whose attributes match those of in, see WithCreateKeys, is read into out instead of
+// creating another one. Requests without the attributes are retried like any other request.
+func (c *Client) Create(ctx context.Context, baseURL, path string, in, out interface{}) error {
+	if c.readOnly {
+		// Refused upfront: a refused POST must neither be retried nor adopt an existing resource.
+		return &ReadOnlyError{Method: http.MethodPost, URL: baseURL + path}
+	}
+	reqBody, err := json.Marshal(&in)
+	if err != nil {
+		return err
+	}
+	match := newCreateMatch(reqBody, createKeys(ctx))
+	listPath := createListPath(ctx, path)
+	noRetry := ctx.Value(noCreateRetryContextKey{}) != nil
+	createCtx := context.WithValue(ctx, createContextKey{}, newIdempotencyKey())
+
+	start := time.Now()
+	for attempt := 0; ; attempt++ {
+		err := c.create(createCtx, baseURL, path, reqBody, out)
+		if err == nil || ctx.Err() != nil || noRetry || attempt >= c.retryClient.RetryMax || !isAmbiguousCreateError(err) {
+			return err
+		}
+		if match != nil {
+			c.log(ctx, LogSubsystemAPI, LogWarn, "Create failed, looking for a resource it may have created", map[string]interface{}{
+				"url":   baseURL + listPath,
+				"match": match.String(),
+				"error": err.Error(),
+			})
+			found, lerr := c.adoptCreated(ctx, baseURL, listPath, match, start, out)
+			if lerr != nil {
+				return fmt.Errorf("%w (and checking whether it created %s failed: %v)", err, match, lerr)
+			}
+			if found {
+				return nil
+			}
+		}
+
+		wait := c.retryClient.Backoff(c.retryClient.RetryWaitMin, c.retryClient.RetryWaitMax, attempt, nil)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// newIdempotencyKey returns a random Idempotency-Key for the attempts of a create.
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func (c *Client) create(ctx context.Context, baseURL, path string, reqBody []byte, out interface{}) error {
+	res, err := c.PostWithBaseURL(ctx, baseURL, path, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	body, err := readBody(res, http.StatusCreated)
+	var apiErr *APIError
+	if err != nil && !errors.As(err, &apiErr) {
+		// The resource was created, but its response was lost.
+		return &createError{err: err}
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, &out)
+}
+
+// createError is a POST sent by Create that failed without a complete response. Errors before the
+// request was sent, e.g. of the rate limiter, aren't createErrors.
+type createError struct {
+	err error
+}
+
+func (e *createError) Error() string {
+	return e.err.Error()
+}
+
+func (e *createError) Unwrap() error {
+	return e.err
+}
+
+// sentRequestError wraps err, the failure of a request sent with ctx, in a createError for Create.
+func sentRequestError(ctx context.Context, err error) error {
+	if isCreate(ctx) {
+		return &createError{err: err}
+	}
+	return err
+}
+
+func isAmbiguousCreateError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500 && apiErr.StatusCode != http.StatusServiceUnavailable
+	}
+	var createErr *createError
+	return errors.As(err, &createErr) && ambiguousCreateFailure(nil, createErr.err)
+}
+
+// createMatch is the attributes a resource created by Create is recognized by, see WithCreateKeys.
+type createMatch map[string]interface{}
+
+// newCreateMatch returns the values of keys in reqBody and its team_id, or nil if one of the keys
+// isn't set. Requests choosing the team by team_name get nil too: resources are listed with their
+// team_id only, so a resource of another team with the same keys couldn't be told apart.
+func newCreateMatch(reqBody []byte, keys []string) createMatch {
+	var attrs map[string]interface{}
+	if err := json.Unmarshal(reqBody, &attrs); err != nil || len(keys) == 0 || attrs["team_name"] != nil {
+		return nil
+	}
+	match := createMatch{}
+	for _, key := range keys {
+		if attrs[key] == nil {
+			return nil
+		}
+		match[key] = attrs[key]
+	}
+	if attrs["team_id"] != nil {
+		match["team_id"] = attrs["team_id"]
+	}
+	return match
+}
+
+func (m createMatch) matches(attrs map[string]interface{}) bool {
+	for key, v := range m {
+		if !reflect.DeepEqual(attrs[key], v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m createMatch) String() string {
+	var parts []string
+	for key, v := range m {
+		b, _ := json.Marshal(v)
+		parts = append(parts, fmt.Sprintf("%s=%s", key, b))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+// adoptCreated looks for a single resource matching match created since start in the collection at
+// baseURL+path, and reads it into out. Resources without created_at are never adopted, they may
+// have existed before.
+func (c *Client) adoptCreated(ctx context.Context, baseURL, path string, match createMatch, start time.Time, out interface{}) (bool, error) {
+	var ids []string
+	for item, err := range Paginate[map[string]interface{}](ctx, c, baseURL, path) {
+		if err != nil {
+			return false, err
+		}
+		if !match.matches(item.Attributes) {
+			continue
+		}
+		createdAt, ok := item.Attributes["created_at"].(string)
+		if !ok {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, createdAt); err != nil || t.Before(start.Add(-createdAtTolerance)) {
+			continue
+		}
+		ids = append(ids, item.ID)
+	}
+	switch len(ids) {
+	case 0:
+		return false, nil
+	case 1:
+		c.log(ctx, LogSubsystemAPI, LogInfo, "Adopting the resource created by the failed create", map[string]interface{}{
+			"url":   baseURL + path,
+			"match": match.String(),
+			"id":    ids[0],
+		})
+		return true, c.Read(ctx, baseURL, path+"/"+ids[0], out)
+	default:
+		return false, fmt.Errorf("found several resources with %s: %s", match, strings.Join(ids, ", "))
+	}
+}
@@ -0,0 +1,278 @@
+package betterstack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCreateAdoptsCreatedResource(t *testing.T) {
+	recent := time.Now().UTC().Format(time.RFC3339)
+	old := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		name string
+		// list is the data of the collection listed after the first POST failed.
+		list         string
+		wantID       string
+		wantRequests string
+		wantErr      string
+	}{
+		{
+			name:         "adopt",
+			list:         `[{"id":"1","attributes":{"name":"other","created_at":"` + recent + `"}},{"id":"2","attributes":{"name":"mine","created_at":"` + recent + `"}}]`,
+			wantID:       "2",
+			wantRequests: "POST /api/v2/sources,GET /api/v2/sources?page=1,GET /api/v2/sources/2",
+		},
+		{
+			name:         "older resource with the same name",
+			list:         `[{"id":"1","attributes":{"name":"mine","created_at":"` + old + `"}}]`,
+			wantID:       "3",
+			wantRequests: "POST /api/v2/sources,GET /api/v2/sources?page=1,POST /api/v2/sources",
+		},
+		{
+			name:         "several candidates",
+			list:         `[{"id":"1","attributes":{"name":"mine","created_at":"` + recent + `"}},{"id":"2","attributes":{"name":"mine","created_at":"` + recent + `"}}]`,
+			wantErr:      `found several resources with name="mine": 1, 2`,
+			wantRequests: "POST /api/v2/sources,GET /api/v2/sources?page=1",
+		},
+		{
+			name:         "resource without created_at",
+			list:         `[{"id":"1","attributes":{"name":"mine"}}]`,
+			wantID:       "3",
+			wantRequests: "POST /api/v2/sources,GET /api/v2/sources?page=1,POST /api/v2/sources",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var requests []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				requests = append(requests, r.Method+" "+r.RequestURI)
+				posts := strings.Count(strings.Join(requests, ","), "POST")
+				mu.Unlock()
+				switch {
+				case r.Method == http.MethodPost && posts == 1:
+					// The resource may or may not have been created.
+					w.WriteHeader(http.StatusBadGateway)
+				case r.Method == http.MethodPost:
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(`{"data":{"id":"3","attributes":{"name":"mine"}}}`))
+				case r.RequestURI == "/api/v2/sources?page=1":
+					_, _ = w.Write([]byte(`{"data":` + tt.list + `,"pagination":{"next":null}}`))
+				case r.RequestURI == "/api/v2/sources/2":
+					_, _ = w.Write([]byte(`{"data":{"id":"2","attributes":{"name":"mine"}}}`))
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			}))
+			defer server.Close()
+
+			c, err := New(Config{BaseURL: server.URL, RetryMax: 2, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond})
+			if err != nil {
+				t.Fatal(err)
+			}
+			name := "mine"
+			item, err := c.Sources().Create(context.Background(), &Source{Name: &name})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("got error %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			} else if item.ID != tt.wantID {
+				t.Errorf("got ID %s, want %s", item.ID, tt.wantID)
+			}
+			if got := strings.Join(requests, ","); got != tt.wantRequests {
+				t.Errorf("got requests %s, want %s", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestCreateRetriesRejectedRequests(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected %s %s", r.Method, r.RequestURI)
+		}
+		if requests == 1 {
+			// Rejected before creating anything, retried without looking for the resource.
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"data":{"id":"1","attributes":{"name":"mine"}}}`))
+	}))
+	defer server.Close()
+
+	c, err := New(Config{BaseURL: server.URL, RetryMax: 1, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := "mine"
+	item, err := c.Sources().Create(context.Background(), &Source{Name: &name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.ID != "1" || requests != 2 {
+		t.Errorf("got ID %s after %d requests", item.ID, requests)
+	}
+}
+
+func TestCreateMatchesCreateKeys(t *testing.T) {
+	recent := time.Now().UTC().Format(time.RFC3339)
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.RequestURI)
+		switch {
+		case r.Method == http.MethodPost && len(requests) == 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"data":{"id":"2","attributes":{"region":"eu-west-1","name":"/aws/lambda/x"}}}`))
+		case r.Method == http.MethodGet:
+			// The same name in another region isn't the override being created.
+			_, _ = w.Write([]byte(`{"data":[{"id":"1","attributes":{"region":"us-east-1","name":"/aws/lambda/x","created_at":"` + recent + `"}}],"pagination":{"next":null}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(Config{BaseURL: server.URL, RetryMax: 2, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := WithCreateKeys(context.Background(), "region", "name")
+	item, err := c.SourceAWSLogGroups("1").Create(ctx, &SourceAWSLogGroup{Region: "eu-west-1", Name: "/aws/lambda/x", Subscribed: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "POST /api/v2/sources/1/aws-log-group-subscriptions,GET /api/v2/sources/1/aws-log-group-subscriptions?page=1,POST /api/v2/sources/1/aws-log-group-subscriptions"
+	if got := strings.Join(requests, ","); item.ID != "2" || got != want {
+		t.Errorf("got ID %s after requests %s, want 2 after %s", item.ID, got, want)
+	}
+}
+
+func TestCreateListsCreateListPath(t *testing.T) {
+	recent := time.Now().UTC().Format(time.RFC3339)
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.RequestURI)
+		switch {
+		case r.Method == http.MethodPost && r.RequestURI == "/api/v2/dashboards/import":
+			w.WriteHeader(http.StatusBadGateway)
+		case r.RequestURI == "/api/v2/dashboards?page=1":
+			_, _ = w.Write([]byte(`{"data":[{"id":"2","attributes":{"name":"mine","created_at":"` + recent + `"}}],"pagination":{"next":null}}`))
+		case r.RequestURI == "/api/v2/dashboards/2":
+			_, _ = w.Write([]byte(`{"data":{"id":"2","attributes":{"name":"mine"}}}`))
+		default:
+			// The import endpoint is no collection, it can't be listed.
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(Config{BaseURL: server.URL, RetryMax: 2, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out Response[Dashboard]
+	ctx := WithCreateListPath(context.Background(), "/api/v2/dashboards")
+	name := "mine"
+	if err := c.Create(ctx, server.URL, "/api/v2/dashboards/import", &Dashboard{Name: &name}, &out); err != nil {
+		t.Fatal(err)
+	}
+	want := "POST /api/v2/dashboards/import,GET /api/v2/dashboards?page=1,GET /api/v2/dashboards/2"
+	if got := strings.Join(requests, ","); out.Data.ID != "2" || got != want {
+		t.Errorf("got ID %s after requests %s, want 2 after %s", out.Data.ID, got, want)
+	}
+}
+
+func TestCreateSendsIdempotencyKey(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			// A request choosing the team by team_name can't be matched, nothing is listed.
+			t.Errorf("unexpected %s %s", r.Method, r.RequestURI)
+		}
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"data":{"id":"1","attributes":{"name":"mine"}}}`))
+	}))
+	defer server.Close()
+
+	c, err := New(Config{BaseURL: server.URL, RetryMax: 2, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, team := "mine", "Ops"
+	if _, err := c.Sources().Create(context.Background(), &Source{Name: &name, TeamName: &team}); err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("got Idempotency-Keys %q, want the same key for both attempts", keys)
+	}
+
+	if _, err := c.Sources().Create(context.Background(), &Source{Name: &name, TeamName: &team}); err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 3 || keys[2] == keys[0] {
+		t.Errorf("got Idempotency-Keys %q, want a new key for another create", keys)
+	}
+}
+
+func TestNewCreateMatch(t *testing.T) {
+	for _, tt := range []struct {
+		body string
+		keys []string
+		want string
+	}{
+		{`{"name":"mine","platform":"ubuntu"}`, []string{"name"}, `name="mine"`},
+		{`{"region":"eu-west-1","name":"/aws/lambda/x"}`, []string{"region", "name"}, `name="/aws/lambda/x", region="eu-west-1"`},
+		{`{"name":"mine","team_id":123}`, []string{"name"}, `name="mine", team_id=123`},
+		// A resource of another team can't be told apart by team_name, nothing is adopted.
+		{`{"name":"mine","team_name":"Ops"}`, []string{"name"}, ""},
+		{`{"platform":"ubuntu"}`, []string{"name"}, ""},
+		{`{"name":"mine"}`, nil, ""},
+	} {
+		match := newCreateMatch([]byte(tt.body), tt.keys)
+		got := ""
+		if match != nil {
+			got = match.String()
+		}
+		if got != tt.want {
+			t.Errorf("newCreateMatch(%s, %v) = %q, want %q", tt.body, tt.keys, got, tt.want)
+		}
+	}
+}
+
+func TestCreateDoesNotAdoptAfterUnsentRequests(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	c, err := New(Config{BaseURL: server.URL, RetryMax: 2, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The request can't be built, so it's never sent and nothing can have been created.
+	name := "mine"
+	err = c.Create(context.Background(), server.URL, "/api/v2/sources\x7f", &Source{Name: &name}, nil)
+	if err == nil || isAmbiguousCreateError(err) || requests != 0 {
+		t.Errorf("got error %v after %d requests, want a failure without requests", err, requests)
+	}
+}
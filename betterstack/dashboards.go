@@ -0,0 +1,86 @@
+package betterstack
+
+import "net/url"
+
+type DashboardVariable struct {
+	Name          *string  `json:"name,omitempty"`
+	VariableType  *string  `json:"variable_type,omitempty"`
+	Values        []string `json:"values,omitempty"`
+	DefaultValues []string `json:"default_values,omitempty"`
+	SQLDefinition *string  `json:"sql_definition,omitempty"`
+}
+
+type Dashboard struct {
+	Name                 *string             `json:"name,omitempty"`
+	Data                 interface{}         `json:"data,omitempty"`
+	TeamName             *string             `json:"team_name,omitempty"`
+	TeamId               *int                `json:"team_id,omitempty"`
+	DashboardGroupID     *int                `json:"dashboard_group_id,omitempty"`
+	RefreshInterval      *int                `json:"refresh_interval,omitempty"`
+	DateRangeFrom        *string             `json:"date_range_from,omitempty"`
+	DateRangeTo          *string             `json:"date_range_to,omitempty"`
+	SourceEligibilitySQL *string             `json:"source_eligibility_sql,omitempty"`
+	Variables            []DashboardVariable `json:"variables,omitempty"`
+	CreatedAt            *string             `json:"created_at,omitempty"`
+	UpdatedAt            *string             `json:"updated_at,omitempty"`
+}
+
+type DashboardGroup struct {
+	Name      *string `json:"name,omitempty"`
+	CreatedAt *string `json:"created_at,omitempty"`
+	UpdatedAt *string `json:"updated_at,omitempty"`
+	TeamName  *string `json:"team_name,omitempty"`
+}
+
+type DashboardSection struct {
+	Name        *string `json:"name,omitempty"`
+	Y           *int    `json:"y,omitempty"`
+	Collapsed   *bool   `json:"collapsed,omitempty"`
+	Explanation *string `json:"explanation,omitempty"`
+	CreatedAt   *string `json:"created_at,omitempty"`
+	UpdatedAt   *string `json:"updated_at,omitempty"`
+}
+
+type DashboardChartQuery struct {
+	ID             *int    `json:"id,omitempty"`
+	Name           *string `json:"name,omitempty"`
+	QueryType      *string `json:"query_type,omitempty"`
+	SQLQuery       *string `json:"sql_query,omitempty"`
+	WhereCondition *string `json:"where_condition,omitempty"`
+	StaticText     *string `json:"static_text,omitempty"`
+	SourceVariable *string `json:"source_variable,omitempty"`
+}
+
+type DashboardChart struct {
+	ChartType   *string                `json:"chart_type,omitempty"`
+	Name        *string                `json:"name,omitempty"`
+	Description *string                `json:"description,omitempty"`
+	X           *int                   `json:"x,omitempty"`
+	Y           *int                   `json:"y,omitempty"`
+	W           *int                   `json:"w,omitempty"`
+	H           *int                   `json:"h,omitempty"`
+	Settings    map[string]interface{} `json:"settings,omitempty"`
+	Queries     []DashboardChartQuery  `json:"queries,omitempty"`
+	CreatedAt   *string                `json:"created_at,omitempty"`
+	UpdatedAt   *string                `json:"updated_at,omitempty"`
+}
+
+// Dashboards returns the dashboards. The list endpoint only returns a summary of each dashboard.
+func (c *Client) Dashboards() Endpoint[Dashboard] {
+	return newEndpoint[Dashboard](c, c.baseURL, "/api/v2/dashboards")
+}
+
+// DashboardGroups returns the dashboard groups.
+func (c *Client) DashboardGroups() Endpoint[DashboardGroup] {
+	return newEndpoint[DashboardGroup](c, c.baseURL, "/api/v2/dashboard-groups")
+}
+
+// DashboardSections returns the sections of a dashboard.
+func (c *Client) DashboardSections(dashboardID string) Endpoint[DashboardSection] {
+	return newEndpoint[DashboardSection](c, c.baseURL, "/api/v2/dashboards/"+url.PathEscape(dashboardID)+"/sections")
+}
+
+// DashboardCharts returns the charts of a dashboard.
+func (c *Client) DashboardCharts(dashboardID string) Endpoint[DashboardChart] {
+	return newEndpoint[DashboardChart](c, c.baseURL, "/api/v2/dashboards/"+url.PathEscape(dashboardID)+"/charts")
+}
@@ -0,0 +1,61 @@
+package betterstack
+
+import (
+	"context"
+	"iter"
+	"net/url"
+)
+
+// Endpoint is a collection of API resources with attributes T, e.g. Client.Sources.
+type Endpoint[T any] struct {
+	client  *Client
+	baseURL string
+	path    string
+}
+
+func newEndpoint[T any](c *Client, baseURL, path string) Endpoint[T] {
+	return Endpoint[T]{client: c, baseURL: baseURL, path: path}
+}
+
+// Path returns the path of the collection, relative to the base URL of its API.
+func (e Endpoint[T]) Path() string {
+	return e.path
+}
+
+// ItemPath returns the path of the resource with the given ID.
+func (e Endpoint[T]) ItemPath(id string) string {
+	return e.path + "/" + url.PathEscape(id)
+}
+
+// List iterates over all resources of the collection, see Paginate.
+func (e Endpoint[T]) List(ctx context.Context) iter.Seq2[Item[T], error] {
+	return Paginate[T](ctx, e.client, e.baseURL, e.path)
+}
+
+// Get returns the resource with the given ID, use IsNotFound to check whether it exists.
+func (e Endpoint[T]) Get(ctx context.Context, id string) (*Item[T], error) {
+	var out Response[T]
+	if err := e.client.Read(ctx, e.baseURL, e.ItemPath(id), &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// Create creates a resource and returns it as returned by the API.
+func (e Endpoint[T]) Create(ctx context.Context, in *T) (*Item[T], error) {
+	var out Response[T]
+	if err := e.client.Create(ctx, e.baseURL, e.path, in, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// Update changes the attributes of the resource with the given ID that are set in in.
+func (e Endpoint[T]) Update(ctx context.Context, id string, in *T) error {
+	return e.client.Update(ctx, e.baseURL, e.ItemPath(id), in)
+}
+
+// Delete deletes the resource with the given ID. Deleting a resource that doesn't exist succeeds.
+func (e Endpoint[T]) Delete(ctx context.Context, id string) error {
+	return e.client.Remove(ctx, e.baseURL, e.ItemPath(id))
+}
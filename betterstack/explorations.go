@@ -0,0 +1,57 @@
+package betterstack
+
+type ExplorationChart struct {
+	ChartType   *string                `json:"chart_type,omitempty"`
+	Name        *string                `json:"name,omitempty"`
+	Description *string                `json:"description,omitempty"`
+	Settings    map[string]interface{} `json:"settings,omitempty"`
+}
+
+type ExplorationQuery struct {
+	ID             *int    `json:"id,omitempty"`
+	Name           *string `json:"name,omitempty"`
+	QueryType      *string `json:"query_type,omitempty"`
+	SQLQuery       *string `json:"sql_query,omitempty"`
+	WhereCondition *string `json:"where_condition,omitempty"`
+	StaticText     *string `json:"static_text,omitempty"`
+	SourceVariable *string `json:"source_variable,omitempty"`
+}
+
+type ExplorationVariable struct {
+	Name          *string  `json:"name,omitempty"`
+	VariableType  *string  `json:"variable_type,omitempty"`
+	Values        []string `json:"values,omitempty"`
+	DefaultValues []string `json:"default_values,omitempty"`
+	SQLDefinition *string  `json:"sql_definition,omitempty"`
+}
+
+type Exploration struct {
+	Name               *string               `json:"name,omitempty"`
+	DateRangeFrom      *string               `json:"date_range_from,omitempty"`
+	DateRangeTo        *string               `json:"date_range_to,omitempty"`
+	ExplorationGroupID *int                  `json:"exploration_group_id,omitempty"`
+	Chart              *ExplorationChart     `json:"chart,omitempty"`
+	Queries            []ExplorationQuery    `json:"queries,omitempty"`
+	Variables          []ExplorationVariable `json:"variables,omitempty"`
+	CreatedAt          *string               `json:"created_at,omitempty"`
+	UpdatedAt          *string               `json:"updated_at,omitempty"`
+	TeamName           *string               `json:"team_name,omitempty"`
+}
+
+type ExplorationGroup struct {
+	Name      *string `json:"name,omitempty"`
+	CreatedAt *string `json:"created_at,omitempty"`
+	UpdatedAt *string `json:"updated_at,omitempty"`
+	TeamName  *string `json:"team_name,omitempty"`
+}
+
+// Explorations returns the saved explorations. The list endpoint only returns a summary of each
+// exploration, without its chart, queries and variables.
+func (c *Client) Explorations() Endpoint[Exploration] {
+	return newEndpoint[Exploration](c, c.baseURL, "/api/v2/explorations")
+}
+
+// ExplorationGroups returns the exploration groups.
+func (c *Client) ExplorationGroups() Endpoint[ExplorationGroup] {
+	return newEndpoint[ExplorationGroup](c, c.baseURL, "/api/v2/exploration-groups")
+}
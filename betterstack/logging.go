@@ -0,0 +1,173 @@
+package betterstack
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// Logger receives the structured logs of the client, see Config.Logger. The fields of a request
+// include its request_id, so the logs of its retries and its response can be correlated.
+type Logger interface {
+	Log(ctx context.Context, subsystem string, level LogLevel, msg string, fields map[string]interface{})
+}
+
+type LogLevel int
+
+const (
+	LogDebug LogLevel = iota
+	LogInfo
+	LogWarn
+)
+
+// The subsystems of the client logs.
+const (
+	// LogSubsystemAPI logs requests and responses, with bodies redacted unless Config.LogSensitive.
+	LogSubsystemAPI = "api"
+	// LogSubsystemRetry logs retried requests.
+	LogSubsystemRetry = "retry"
+	// LogSubsystemRateLimit logs waits for the rate limiter and changes of the limit.
+	LogSubsystemRateLimit = "ratelimit"
+)
+
+// RedactedValue replaces the values of sensitive keys in logged bodies.
+const RedactedValue = "***"
+
+// sensitiveKeys are the keys whose values are redacted in logged bodies. A key also matches with
+// a prefix, e.g. scrape_request_basic_auth_password or js_tag_token.
+var sensitiveKeys = []string{
+	"token",
+	"password",
+	"secret",
+	"secret_access_key",
+	"access_key_id",
+	"api_key",
+	"external_id",
+	"private_key",
+}
+
+// sensitiveHeaders are the names of headers, e.g. in scrape_request_headers, whose value is
+// redacted in logged bodies.
+var sensitiveHeaders = []string{
+	"authorization",
+	"cookie",
+	"proxy-authorization",
+	"x-api-key",
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if key == s || strings.HasSuffix(key, "_"+s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactJSON returns body with the values of sensitive keys replaced by RedactedValue. Bodies that
+// aren't JSON are returned unchanged.
+func redactJSON(body []byte) []byte {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return body
+	}
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return body
+	}
+	redacted, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		// A header given as {"name": "Authorization", "value": "..."}.
+		if name, ok := v["name"].(string); ok {
+			if _, ok := v["value"]; ok && isSensitiveHeader(name) {
+				v["value"] = RedactedValue
+			}
+		}
+		for key, value := range v {
+			if isSensitiveKey(key) {
+				if value != nil {
+					v[key] = RedactedValue
+				}
+				continue
+			}
+			v[key] = redactValue(value)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func isSensitiveHeader(name string) bool {
+	for _, h := range sensitiveHeaders {
+		if strings.EqualFold(name, h) {
+			return true
+		}
+	}
+	return false
+}
+
+type requestIDContextKey struct{}
+
+// newRequestID returns a random ID correlating the logs of a request.
+func newRequestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func requestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+func (c *Client) log(ctx context.Context, subsystem string, level LogLevel, msg string, fields map[string]interface{}) {
+	if c.logger == nil {
+		return
+	}
+	if id := requestID(ctx); id != "" {
+		fields["request_id"] = id
+	}
+	c.logger.Log(ctx, subsystem, level, msg, fields)
+}
+
+// logBody returns body as logged, redacted unless Config.LogSensitive is set.
+func (c *Client) logBody(body []byte) string {
+	if c.logSensitive {
+		return string(body)
+	}
+	return string(redactJSON(body))
+}
+
+// logRetry is the RequestLogHook of the retry client, called before every attempt of a request.
+func (c *Client) logRetry(_ retryablehttp.Logger, req *http.Request, attempt int) {
+	if attempt == 0 {
+		return
+	}
+	c.log(req.Context(), LogSubsystemRetry, LogWarn, "Retrying API request", map[string]interface{}{
+		"method":  req.Method,
+		"url":     req.URL.String(),
+		"attempt": attempt,
+	})
+}
@@ -0,0 +1,114 @@
+package betterstack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"source", `{"data":{"id":"1","attributes":{"name":"a","token":"abc","js_tag_token":"def","team_id":1}}}`, `{"data":{"attributes":{"js_tag_token":"***","name":"a","team_id":1,"token":"***"},"id":"1"}}`},
+		{"custom bucket", `{"custom_bucket":{"name":"b","access_key_id":"AKIA","secret_access_key":"s3cr3t"}}`, `{"custom_bucket":{"access_key_id":"***","name":"b","secret_access_key":"***"}}`},
+		{"connection", `{"data":[{"attributes":{"host":"h","password":"p","data_sources":[{"password":"q"}]}}]}`, `{"data":[{"attributes":{"data_sources":[{"password":"***"}],"host":"h","password":"***"}}]}`},
+		{"scrape headers", `{"scrape_request_headers":[{"name":"Authorization","value":"Bearer x"},{"name":"Accept","value":"*/*"}],"scrape_request_basic_auth_password":"y"}`, `{"scrape_request_basic_auth_password":"***","scrape_request_headers":[{"name":"Authorization","value":"***"},{"name":"Accept","value":"*/*"}]}`},
+		{"null secret", `{"password":null}`, `{"password":null}`},
+		{"large number", `{"id":12345678901234567890}`, `{"id":12345678901234567890}`},
+		{"not JSON", `<html>Bad Gateway</html>`, `<html>Bad Gateway</html>`},
+		{"empty", ``, ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(redactJSON([]byte(tt.body))); got != tt.want {
+				t.Errorf("redactJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+type testLog struct {
+	subsystem string
+	level     LogLevel
+	msg       string
+	fields    map[string]interface{}
+}
+
+type testLogger struct {
+	mu   sync.Mutex
+	logs []testLog
+}
+
+func (l *testLogger) Log(ctx context.Context, subsystem string, level LogLevel, msg string, fields map[string]interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logs = append(l.logs, testLog{subsystem, level, msg, fields})
+}
+
+func TestRequestLogging(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("X-Request-Id", "api-123")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"data":{"id":"1","attributes":{"name":"a","token":"secret-token"}}}`))
+	}))
+	defer server.Close()
+
+	for _, sensitive := range []bool{false, true} {
+		requests = 0
+		logger := &testLogger{}
+		c, err := New(Config{BaseURL: server.URL, Token: "api-token", RetryMax: 1, RetryWaitMin: 1, RetryWaitMax: 1, Logger: logger, LogSensitive: sensitive})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := c.Post(context.Background(), "/api/v1/sources", strings.NewReader(`{"name":"a","password":"hunter2"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+
+		var got []string
+		for _, l := range logger.logs {
+			got = append(got, l.subsystem+": "+l.msg)
+			if l.fields["request_id"] == nil || l.fields["request_id"] != logger.logs[0].fields["request_id"] {
+				t.Errorf("%s: got request_id %v, want %v", l.msg, l.fields["request_id"], logger.logs[0].fields["request_id"])
+			}
+		}
+		if want := "api: Sending API request,retry: Retrying API request,api: Received API response"; strings.Join(got, ",") != want {
+			t.Fatalf("got logs %s, want %s", strings.Join(got, ","), want)
+		}
+
+		request, response := logger.logs[0].fields, logger.logs[2].fields
+		wantRequest, wantResponse := `{"name":"a","password":"***"}`, `{"data":{"attributes":{"name":"a","token":"***"},"id":"1"}}`
+		if sensitive {
+			wantRequest, wantResponse = `{"name":"a","password":"hunter2"}`, `{"data":{"id":"1","attributes":{"name":"a","token":"secret-token"}}}`
+		}
+		if request["request_body"] != wantRequest {
+			t.Errorf("got request_body %v, want %s", request["request_body"], wantRequest)
+		}
+		if response["response_body"] != wantResponse || response["status_code"] != http.StatusCreated || response["api_request_id"] != "api-123" {
+			t.Errorf("got response fields %v", response)
+		}
+		if _, ok := response["duration_ms"].(int64); !ok {
+			t.Errorf("got duration_ms %v", response["duration_ms"])
+		}
+		for _, l := range logger.logs {
+			for _, v := range l.fields {
+				if s, ok := v.(string); ok && strings.Contains(s, "api-token") {
+					t.Errorf("%s logged the API token: %s", l.msg, s)
+				}
+			}
+		}
+	}
+}
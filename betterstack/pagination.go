@@ -0,0 +1,78 @@
+package betterstack
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// Item is a single resource in the JSON:API-like envelope of Better Stack responses.
+type Item[T any] struct {
+	ID         string `json:"id"`
+	Type       string `json:"type,omitempty"`
+	Attributes T      `json:"attributes"`
+}
+
+// Response is the response to a request for a single resource.
+type Response[T any] struct {
+	Data Item[T] `json:"data"`
+}
+
+// Pagination holds the page URLs of a list response, Next is empty or null on the last page.
+type Pagination struct {
+	First *string `json:"first"`
+	Last  *string `json:"last"`
+	Prev  *string `json:"prev"`
+	Next  *string `json:"next"`
+}
+
+// Page is one page of a list response. Endpoints that aren't paginated leave Pagination empty.
+type Page[T any] struct {
+	Data       []Item[T]  `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// HasNext reports whether there's a page after p.
+func (p *Page[T]) HasNext() bool {
+	return p.Pagination.Next != nil && *p.Pagination.Next != ""
+}
+
+// Paginate returns an iterator over the items of the list endpoint baseURL+path, requesting
+// ?page=1, ?page=2... until a page without a next link. Pages are fetched lazily, so breaking out
+// of the loop stops the requests. An error is yielded once and ends the iteration.
+func Paginate[T any](ctx context.Context, c *Client, baseURL, path string) iter.Seq2[Item[T], error] {
+	return func(yield func(Item[T], error) bool) {
+		sep := "?"
+		if strings.Contains(path, "?") {
+			sep = "&"
+		}
+		for page := 1; ; page++ {
+			var res Page[T]
+			if err := c.Read(ctx, baseURL, fmt.Sprintf("%s%spage=%d", path, sep, page), &res); err != nil {
+				yield(Item[T]{}, err)
+				return
+			}
+			for _, item := range res.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !res.HasNext() {
+				return
+			}
+		}
+	}
+}
+
+// ListAll collects all items of the list endpoint baseURL+path, see Paginate.
+func ListAll[T any](ctx context.Context, c *Client, baseURL, path string) ([]Item[T], error) {
+	var items []Item[T]
+	for item, err := range Paginate[T](ctx, c, baseURL, path) {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
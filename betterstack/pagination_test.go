@@ -0,0 +1,131 @@
+package betterstack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer foo" {
+			t.Fatal("Not authorized: " + r.Header.Get("Authorization"))
+		}
+		requests = append(requests, r.RequestURI)
+		switch r.RequestURI {
+		case "/api/v2/sources?page=1":
+			_, _ = w.Write([]byte(`{"data":[{"id":"1","attributes":{"name":"a","team_id":123}},{"id":"2","attributes":{"name":"b","team_id":"t123"}}],"pagination":{"next":"https://example.com/api/v2/sources?page=2"}}`))
+		case "/api/v2/sources?page=2":
+			_, _ = w.Write([]byte(`{"data":[{"id":"3","attributes":{"name":"c"}}],"pagination":{"next":null}}`))
+		case "/api/v1/collectors?name=x&page=1":
+			_, _ = w.Write([]byte(`{"data":[],"pagination":{"next":""}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":"boom"}`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(Config{BaseURL: server.URL, Token: "foo", RetryMax: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	items, err := ListAll[Source](ctx, c, c.TelemetryBaseURL(), "/api/v2/sources")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, item := range items {
+		names = append(names, item.ID+":"+*item.Attributes.Name)
+	}
+	if got := strings.Join(names, ","); got != "1:a,2:b,3:c" {
+		t.Errorf("got items %s", got)
+	}
+	if *items[0].Attributes.TeamId != "123" || *items[1].Attributes.TeamId != "t123" {
+		t.Errorf("got team IDs %v, %v", *items[0].Attributes.TeamId, *items[1].Attributes.TeamId)
+	}
+
+	// Breaking out of the loop stops fetching pages.
+	requests = nil
+	for item, err := range c.Sources().List(ctx) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if item.ID == "1" {
+			break
+		}
+	}
+	if len(requests) != 1 {
+		t.Errorf("expected a single request, got %v", requests)
+	}
+
+	// Paths with a query string get &page=.
+	if items, err := ListAll[Collector](ctx, c, c.TelemetryBaseURL(), "/api/v1/collectors?name=x"); err != nil || len(items) != 0 {
+		t.Errorf("got %v, %v", items, err)
+	}
+
+	_, err = ListAll[Metric](ctx, c, c.TelemetryBaseURL(), "/api/v2/sources/9/metrics")
+	if err == nil || err.Error() != "GET "+server.URL+"/api/v2/sources/9/metrics?page=1 returned 400: {\"errors\":\"boom\"}" {
+		t.Errorf("got error %v", err)
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.RequestURI {
+		case "POST /api/v1/source-groups":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"data":{"id":"7","attributes":{"name":"Production","sort_index":1}}}`))
+		case "GET /api/v1/source-groups/7":
+			_, _ = w.Write([]byte(`{"data":{"id":"7","attributes":{"name":"Production","sort_index":1}}}`))
+		case "PATCH /api/v1/source-groups/7":
+			_, _ = w.Write([]byte(`{"data":{"id":"7","attributes":{"name":"Staging","sort_index":1}}}`))
+		case "DELETE /api/v1/source-groups/7":
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /api/v2/applications/8":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"errors":"unexpected"}`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(Config{BaseURL: server.URL, Token: "foo", RetryMax: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	groups := c.SourceGroups()
+
+	name := "Production"
+	created, err := groups.Create(ctx, &SourceGroup{Name: &name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.ID != "7" || *created.Attributes.SortIndex != 1 {
+		t.Errorf("got %+v", created)
+	}
+	if got, err := groups.Get(ctx, "7"); err != nil || *got.Attributes.Name != "Production" {
+		t.Errorf("got %+v, %v", got, err)
+	}
+	name = "Staging"
+	if err := groups.Update(ctx, "7", &SourceGroup{Name: &name}); err != nil {
+		t.Error(err)
+	}
+	if err := groups.Delete(ctx, "7"); err != nil {
+		t.Error(err)
+	}
+	if _, err := c.ErrorsApplications().Get(ctx, "8"); !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	err = groups.Update(ctx, "8", &SourceGroup{Name: &name})
+	if apiErr, ok := err.(*APIError); !ok || apiErr.Method != http.MethodPatch || apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("expected an APIError, got %v", err)
+	}
+}
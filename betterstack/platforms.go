@@ -0,0 +1,17 @@
+package betterstack
+
+// Platform is an integration of the platform catalog, the valid platforms of sources and errors
+// applications.
+type Platform struct {
+	Name *string `json:"name,omitempty"`
+	// Kind is logs, metrics or errors. Sources accept logs and metrics platforms, errors
+	// applications accept errors platforms.
+	Kind        *string `json:"kind,omitempty"`
+	DisplayName *string `json:"display_name,omitempty"`
+	DocsURL     *string `json:"docs_url,omitempty"`
+}
+
+// Platforms returns the platform catalog.
+func (c *Client) Platforms() Endpoint[Platform] {
+	return newEndpoint[Platform](c, c.baseURL, "/api/v2/platforms")
+}
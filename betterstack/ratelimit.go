@@ -0,0 +1,145 @@
+package betterstack
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"golang.org/x/time/rate"
+)
+
+// The API reports its rate limit in response headers. Retry-After and X-RateLimit-Reset tell how
+// long to wait after a 429, X-RateLimit-Remaining how many requests are left until the reset.
+const (
+	headerRetryAfter         = "Retry-After"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// Waits for the rate limiter shorter than this aren't logged.
+const rateLimitLogThreshold = 100 * time.Millisecond
+
+// X-RateLimit-Reset values above this are a Unix timestamp, smaller ones a number of seconds.
+const unixTimestampThreshold = 1_000_000_000
+
+// checkRetry is the CheckRetry policy of the client. It retries 429 responses on top of the
+// default policy, and adapts the rate limiter to every response, including those retried. Failed
+// POSTs that may have created the resource are left to Create, see ambiguousCreateFailure.
+func (c *Client) checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if resp != nil {
+		c.adaptRateLimit(ctx, resp)
+	}
+	if ctx.Err() == nil && isCreate(ctx) && ambiguousCreateFailure(resp, err) {
+		return false, nil
+	}
+	return rateLimitRetryPolicy(ctx, resp, err)
+}
+
+// backoff waits as long as the Retry-After or X-RateLimit-Reset header of a 429 or 503 response
+// asks, even beyond RetryWaitMax since retrying earlier would only fail again. Other responses,
+// and those without the headers, use exponential backoff between RetryWaitMin and RetryWaitMax.
+func backoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := rateLimitWait(resp.Header, time.Now()); ok {
+			return wait
+		}
+	}
+	return retryablehttp.DefaultBackoff(min, max, attemptNum, nil)
+}
+
+// rateLimitWait returns the wait asked for by the Retry-After header, in seconds or as an HTTP
+// date, or else by X-RateLimit-Reset.
+func rateLimitWait(header http.Header, now time.Time) (time.Duration, bool) {
+	if v := header.Get(headerRetryAfter); v != "" {
+		if seconds, err := strconv.ParseInt(v, 10, 64); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return nonNegative(t.Sub(now)), true
+		}
+	}
+	return rateLimitReset(header, now)
+}
+
+// rateLimitReset returns the time until the rate limit window resets, from X-RateLimit-Reset
+// holding either a number of seconds or a Unix timestamp.
+func rateLimitReset(header http.Header, now time.Time) (time.Duration, bool) {
+	v := header.Get(headerRateLimitReset)
+	if v == "" {
+		return 0, false
+	}
+	reset, err := strconv.ParseFloat(v, 64)
+	if err != nil || reset < 0 {
+		return 0, false
+	}
+	if reset > unixTimestampThreshold {
+		return nonNegative(time.Unix(0, int64(reset*float64(time.Second))).Sub(now)), true
+	}
+	return time.Duration(reset * float64(time.Second)), true
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// adaptRateLimit spreads the requests left in the current rate limit window, as reported by
+// X-RateLimit-Remaining and X-RateLimit-Reset, over the rest of the window. The limiter never
+// exceeds the configured RateLimit and RateBurst, and speeds up again once a new window starts.
+func (c *Client) adaptRateLimit(ctx context.Context, resp *http.Response) {
+	remaining, err := strconv.Atoi(resp.Header.Get(headerRateLimitRemaining))
+	if err != nil || remaining < 0 {
+		return
+	}
+	window, ok := rateLimitReset(resp.Header, time.Now())
+	if !ok {
+		return
+	}
+	if window < time.Second {
+		window = time.Second
+	}
+
+	// Keep one request per window, so the limiter doesn't block forever on an exhausted limit -
+	// the retry backoff waits for the reset if that request is rejected.
+	if remaining < 1 {
+		remaining = 1
+	}
+	limit := rate.Limit(float64(remaining) / window.Seconds())
+	if limit > c.maxRateLimit {
+		limit = c.maxRateLimit
+	}
+	burst := remaining
+	if c.maxRateBurst > 0 && burst > c.maxRateBurst {
+		burst = c.maxRateBurst
+	}
+
+	if limit != c.rateLimiter.Limit() || burst != c.rateLimiter.Burst() {
+		c.log(ctx, LogSubsystemRateLimit, LogDebug, "Adapting rate limit", map[string]interface{}{
+			"remaining":  remaining,
+			"window_ms":  window.Milliseconds(),
+			"rate_limit": float64(limit),
+			"burst":      burst,
+		})
+	}
+	now := time.Now()
+	c.rateLimiter.SetLimitAt(now, limit)
+	c.rateLimiter.SetBurstAt(now, burst)
+}
+
+// waitRateLimit waits for the rate limiter, logging waits long enough to notice.
+func (c *Client) waitRateLimit(ctx context.Context) error {
+	start := time.Now()
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+	if wait := time.Since(start); wait >= rateLimitLogThreshold {
+		c.log(ctx, LogSubsystemRateLimit, LogDebug, "Waited for the rate limiter", map[string]interface{}{
+			"wait_ms": wait.Milliseconds(),
+		})
+	}
+	return nil
+}
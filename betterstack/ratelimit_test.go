@@ -0,0 +1,129 @@
+package betterstack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestRateLimitWait(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+		ok     bool
+	}{
+		{"none", http.Header{}, 0, false},
+		{"retry-after seconds", http.Header{"Retry-After": {"7"}}, 7 * time.Second, true},
+		{"retry-after date", http.Header{"Retry-After": {now.Add(90 * time.Second).Format(http.TimeFormat)}}, 90 * time.Second, true},
+		{"retry-after date in the past", http.Header{"Retry-After": {now.Add(-time.Minute).Format(http.TimeFormat)}}, 0, true},
+		{"retry-after takes precedence", http.Header{"Retry-After": {"3"}, "X-Ratelimit-Reset": {"30"}}, 3 * time.Second, true},
+		{"reset seconds", http.Header{"X-Ratelimit-Reset": {"12"}}, 12 * time.Second, true},
+		{"reset timestamp", http.Header{"X-Ratelimit-Reset": {strconv.FormatInt(now.Add(45*time.Second).Unix(), 10)}}, 45 * time.Second, true},
+		{"invalid", http.Header{"Retry-After": {"soon"}, "X-Ratelimit-Reset": {"later"}}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := rateLimitWait(tt.header, now)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("rateLimitWait() = %v, %v, want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requestCount, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// The Retry-After header overrides both wait bounds.
+	c, err := New(Config{BaseURL: server.URL, RetryMax: 1, RetryWaitMin: time.Minute, RetryWaitMax: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	resp, err := c.Get(context.Background(), "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if elapsed := time.Since(start); elapsed < time.Second || elapsed > 10*time.Second {
+		t.Errorf("expected to wait about 1s, waited %v", elapsed)
+	}
+	if resp.StatusCode != http.StatusOK || atomic.LoadInt32(&requestCount) != 2 {
+		t.Errorf("expected a successful retry, got %d after %d requests", resp.StatusCode, requestCount)
+	}
+}
+
+func TestAdaptRateLimit(t *testing.T) {
+	var remaining atomic.Int32
+	remaining.Store(100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(int(remaining.Load())))
+		w.Header().Set("X-RateLimit-Reset", "10")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	get := func(c *Client) {
+		resp, err := c.Get(context.Background(), "/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	c, err := New(Config{BaseURL: server.URL, RateLimit: 8, RateBurst: 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Plenty of requests left, the configured limit applies.
+	get(c)
+	if c.rateLimiter.Limit() != 8 || c.rateLimiter.Burst() != 16 {
+		t.Errorf("got limit %v, burst %d, want the configured 8, 16", c.rateLimiter.Limit(), c.rateLimiter.Burst())
+	}
+	// 5 requests left in the next 10 seconds.
+	remaining.Store(5)
+	get(c)
+	if c.rateLimiter.Limit() != 0.5 || c.rateLimiter.Burst() != 5 {
+		t.Errorf("got limit %v, burst %d, want 0.5, 5", c.rateLimiter.Limit(), c.rateLimiter.Burst())
+	}
+	// Exhausted, a single request per window.
+	remaining.Store(0)
+	get(c)
+	if c.rateLimiter.Limit() != 0.1 || c.rateLimiter.Burst() != 1 {
+		t.Errorf("got limit %v, burst %d, want 0.1, 1", c.rateLimiter.Limit(), c.rateLimiter.Burst())
+	}
+	// A new window restores the configured limit.
+	remaining.Store(1000)
+	c.rateLimiter.SetLimit(rate.Inf) // don't wait for the token in the test
+	get(c)
+	if c.rateLimiter.Limit() != 8 || c.rateLimiter.Burst() != 16 {
+		t.Errorf("got limit %v, burst %d, want the configured 8, 16", c.rateLimiter.Limit(), c.rateLimiter.Burst())
+	}
+
+	// Without a configured limit, the client only slows down when the API asks for it.
+	c, err = New(Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	get(c)
+	if c.rateLimiter.Limit() != 100 || c.rateLimiter.Burst() != 1000 {
+		t.Errorf("got limit %v, burst %d, want 100, 1000", c.rateLimiter.Limit(), c.rateLimiter.Burst())
+	}
+}
@@ -0,0 +1,84 @@
+package betterstack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// APIError is returned for responses with an unexpected status code.
+type APIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s returned %d: %s", e.Method, e.URL, e.StatusCode, string(e.Body))
+}
+
+// IsNotFound reports whether err is an APIError with status 404.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// Read GETs baseURL+path and decodes the response into out. A 404 is returned as an APIError,
+// see IsNotFound.
+func (c *Client) Read(ctx context.Context, baseURL, path string, out interface{}) error {
+	res, err := c.GetWithBaseURL(ctx, baseURL, path)
+	if err != nil {
+		return err
+	}
+	body, err := readBody(res, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, &out)
+}
+
+// Update PATCHes baseURL+path with in as JSON.
+func (c *Client) Update(ctx context.Context, baseURL, path string, in interface{}) error {
+	reqBody, err := json.Marshal(&in)
+	if err != nil {
+		return err
+	}
+	res, err := c.PatchWithBaseURL(ctx, baseURL, path, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	_, err = readBody(res, http.StatusOK)
+	return err
+}
+
+// Remove DELETEs baseURL+path. A resource that's already gone isn't an error.
+func (c *Client) Remove(ctx context.Context, baseURL, path string) error {
+	res, err := c.DeleteWithBaseURL(ctx, baseURL, path)
+	if err != nil {
+		return err
+	}
+	_, err = readBody(res, http.StatusNoContent, http.StatusNotFound)
+	return err
+}
+
+// readBody reads and closes the response body, returning an APIError unless the status code is
+// one of expected.
+func readBody(res *http.Response, expected ...int) ([]byte, error) {
+	defer func() {
+		// Keep-Alive.
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+	body, err := io.ReadAll(res.Body)
+	for _, status := range expected {
+		if res.StatusCode == status {
+			return body, err
+		}
+	}
+	return nil, &APIError{Method: res.Request.Method, URL: res.Request.URL.String(), StatusCode: res.StatusCode, Body: body}
+}
@@ -0,0 +1,105 @@
+package betterstack
+
+import "net/url"
+
+type SourceCustomBucket struct {
+	Name                   *string `json:"name,omitempty"`
+	Endpoint               *string `json:"endpoint,omitempty"`
+	AccessKeyID            *string `json:"access_key_id,omitempty"`
+	SecretAccessKey        *string `json:"secret_access_key,omitempty"`
+	KeepDataAfterRetention *bool   `json:"keep_data_after_retention,omitempty"`
+}
+
+type Source struct {
+	Name                           *string                   `json:"name,omitempty"`
+	Token                          *string                   `json:"token,omitempty"`
+	TeamId                         *StringOrInt              `json:"team_id,omitempty"`
+	TableName                      *string                   `json:"table_name,omitempty"`
+	Platform                       *string                   `json:"platform,omitempty"`
+	AWSAutoSubLogGroups            *bool                     `json:"aws_auto_sub_log_groups,omitempty"`
+	AzureSubscriptionIDs           *[]string                 `json:"azure_subscription_ids,omitempty"`
+	AzureAutoSubDiagnosticSettings *bool                     `json:"azure_auto_sub_diagnostic_settings,omitempty"`
+	IngestingHost                  *string                   `json:"ingesting_host,omitempty"`
+	IngestingPaused                *bool                     `json:"ingesting_paused,omitempty"`
+	LogsRetention                  *int                      `json:"logs_retention,omitempty"`
+	MetricsRetention               *int                      `json:"metrics_retention,omitempty"`
+	LiveTailPattern                *string                   `json:"live_tail_pattern,omitempty"`
+	CreatedAt                      *string                   `json:"created_at,omitempty"`
+	UpdatedAt                      *string                   `json:"updated_at,omitempty"`
+	TeamName                       *string                   `json:"team_name,omitempty"`
+	ScrapeURLs                     *[]string                 `json:"scrape_urls,omitempty"`
+	ScrapeFrequencySecs            *int                      `json:"scrape_frequency_secs,omitempty"`
+	ScrapeRequestHeaders           *[]map[string]interface{} `json:"scrape_request_headers,omitempty"`
+	ScrapeRequestBasicAuthUser     *string                   `json:"scrape_request_basic_auth_user,omitempty"`
+	ScrapeRequestBasicAuthPassword *string                   `json:"scrape_request_basic_auth_password,omitempty"`
+	SkipSSLVerify                  *bool                     `json:"skip_ssl_verify,omitempty"`
+	DataRegion                     *string                   `json:"data_region,omitempty"`
+	SourceGroupID                  *int                      `json:"source_group_id,omitempty"`
+	CustomBucket                   *SourceCustomBucket       `json:"custom_bucket,omitempty"`
+	VrlTransformationLogs          *string                   `json:"vrl_transformation_logs,omitempty"`
+	VrlTransformationSpans         *string                   `json:"vrl_transformation_spans,omitempty"`
+	BlockedMetrics                 *[]string                 `json:"blocked_metrics,omitempty"`
+	CodeMappingStackRoot           *string                   `json:"code_mapping_stack_root,omitempty"`
+	CodeMappingSourceRoot          *string                   `json:"code_mapping_source_root,omitempty"`
+}
+
+type SourceGroup struct {
+	Name      *string `json:"name,omitempty"`
+	SortIndex *int    `json:"sort_index,omitempty"`
+	CreatedAt *string `json:"created_at,omitempty"`
+	UpdatedAt *string `json:"updated_at,omitempty"`
+	TeamName  *string `json:"team_name,omitempty"`
+}
+
+type SourceAWSLogGroup struct {
+	Region     string `json:"region"`
+	Name       string `json:"name"`
+	Subscribed bool   `json:"subscribed"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	UpdatedAt  string `json:"updated_at,omitempty"`
+}
+
+// SourceTokenRotation replaces the ingestion token of a source. GracePeriod is the number of
+// seconds the previous token keeps being accepted, 0 revokes it right away.
+type SourceTokenRotation struct {
+	GracePeriod            *int    `json:"grace_period,omitempty"`
+	Token                  *string `json:"token,omitempty"`
+	PreviousToken          *string `json:"previous_token,omitempty"`
+	PreviousTokenExpiresAt *string `json:"previous_token_expires_at,omitempty"`
+	CreatedAt              *string `json:"created_at,omitempty"`
+}
+
+type Metric struct {
+	SourceID      *string   `json:"source_id,omitempty"`
+	Name          *string   `json:"name,omitempty"`
+	SQLExpression *string   `json:"sql_expression,omitempty"`
+	Aggregations  *[]string `json:"aggregations,omitempty"`
+	Type          *string   `json:"type,omitempty"`
+}
+
+// Sources returns the Telemetry sources.
+func (c *Client) Sources() Endpoint[Source] {
+	return newEndpoint[Source](c, c.baseURL, "/api/v2/sources")
+}
+
+// SourceGroups returns the Telemetry source groups.
+func (c *Client) SourceGroups() Endpoint[SourceGroup] {
+	return newEndpoint[SourceGroup](c, c.baseURL, "/api/v1/source-groups")
+}
+
+// Metrics returns the metrics and labels of a source.
+func (c *Client) Metrics(sourceID string) Endpoint[Metric] {
+	return newEndpoint[Metric](c, c.baseURL, "/api/v2/sources/"+url.PathEscape(sourceID)+"/metrics")
+}
+
+// SourceAWSLogGroups returns the explicit CloudWatch log-group subscription overrides of an AWS
+// source.
+func (c *Client) SourceAWSLogGroups(sourceID string) Endpoint[SourceAWSLogGroup] {
+	return newEndpoint[SourceAWSLogGroup](c, c.baseURL, "/api/v2/sources/"+url.PathEscape(sourceID)+"/aws-log-group-subscriptions")
+}
+
+// SourceTokenRotations returns the token rotations of a source. Creating one replaces the token
+// of the source.
+func (c *Client) SourceTokenRotations(sourceID string) Endpoint[SourceTokenRotation] {
+	return newEndpoint[SourceTokenRotation](c, c.baseURL, "/api/v2/sources/"+url.PathEscape(sourceID)+"/token-rotations")
+}
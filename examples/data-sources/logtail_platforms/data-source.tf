@@ -0,0 +1,7 @@
+data "logtail_platforms" "errors" {
+  kind = "errors"
+}
+
+output "errors_platforms" {
+  value = { for p in data.logtail_platforms.errors.platforms : p.name => p.docs_url }
+}
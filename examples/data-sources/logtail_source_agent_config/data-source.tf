@@ -0,0 +1,12 @@
+# Vector configuration shipping application logs to a source
+data "logtail_source_agent_config" "vector" {
+  source_id = logtail_source.this.id
+  agent     = "vector"
+  paths     = ["/var/log/app/*.log"]
+}
+
+# e.g. for configuration management to write to /etc/vector/vector.toml
+output "vector_config" {
+  value     = data.logtail_source_agent_config.vector.config
+  sensitive = true
+}
@@ -0,0 +1,7 @@
+data "logtail_sources" "kubernetes" {
+  platform = "kubernetes"
+}
+
+output "kubernetes_source_table_names" {
+  value = [for s in data.logtail_sources.kubernetes.sources : s.table_name]
+}
@@ -0,0 +1,19 @@
+# Short-lived ClickHouse credentials (Terraform 1.10+): created when Terraform opens the
+# ephemeral resource and deleted when it closes it, the password never reaches the state.
+# Managing connections requires a global API token (not a team token).
+ephemeral "logtail_connection" "grafana" {
+  client_type  = "clickhouse"
+  team_names   = ["My Team"]
+  ip_allowlist = ["203.0.113.0/24"]
+
+  # Expire the credentials even if Terraform is interrupted before closing them
+  valid_until = "2030-01-01T00:00:00Z"
+}
+
+# Hand the credentials to another provider, e.g. a ClickHouse or Grafana provider
+provider "clickhouse" {
+  host     = ephemeral.logtail_connection.grafana.host
+  port     = ephemeral.logtail_connection.grafana.port
+  username = ephemeral.logtail_connection.grafana.username
+  password = ephemeral.logtail_connection.grafana.password
+}
@@ -0,0 +1,5 @@
+# Create a dashboard from an exported file, reformatting the file doesn't re-create the dashboard
+resource "logtail_dashboard" "imported" {
+  name = "Imported dashboard"
+  data = provider::logtail::dashboard_json_normalize(file("${path.module}/dashboard.json"))
+}
@@ -0,0 +1,4 @@
+# Compare a VRL program with the one stored by Better Stack, which ends it with "\n."
+output "transformation_changed" {
+  value = provider::logtail::normalize_vrl(file("${path.module}/transformation.vrl")) != provider::logtail::normalize_vrl(logtail_collector.this.vrl_transformation)
+}
@@ -0,0 +1,6 @@
+# Reference the dashboard and chart of an alert imported as "dashboard_id/chart_id/alert_id"
+locals {
+  alert_ids    = provider::logtail::parse_composite_id(logtail_dashboard_alert.imported.id)
+  dashboard_id = local.alert_ids[0]
+  chart_id     = local.alert_ids[1]
+}
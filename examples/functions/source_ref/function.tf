@@ -0,0 +1,9 @@
+# Pin an alert to a specific source by table name
+resource "logtail_exploration_alert" "errors" {
+  exploration_id  = logtail_exploration.this.id
+  name            = "Errors"
+  alert_type      = "threshold"
+  operator        = "higher_than"
+  value           = 10
+  source_variable = provider::logtail::source_ref(logtail_source.this.table_name)
+}
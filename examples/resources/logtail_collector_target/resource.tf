@@ -81,3 +81,16 @@ resource "logtail_collector_target" "paused_replica" {
   ssl_mode     = "disable"
   enabled      = false
 }
+
+# Write-only password (Terraform 1.11+) - sent to Better Stack but never stored in the state.
+# Increment password_wo_version whenever the password changes.
+resource "logtail_collector_target" "reporting_db" {
+  collector_id        = logtail_collector.production.id
+  kind                = "postgres"
+  host                = "reporting.example.com"
+  port                = 5432
+  username            = "monitor"
+  password_wo         = "example-rotate-me"
+  password_wo_version = 1
+  ssl_mode            = "require"
+}
@@ -0,0 +1,28 @@
+# Manage all log-group overrides of a source in one resource, e.g. for many
+# Lambda functions. Overrides not listed here are deleted on the next apply.
+resource "logtail_source" "aws_lambdas" {
+  name     = "AWS Lambdas"
+  platform = "aws"
+}
+
+locals {
+  lambda_functions = ["checkout", "payments", "notifications"]
+}
+
+resource "logtail_source_aws_log_groups" "lambdas" {
+  source_id = logtail_source.aws_lambdas.id
+
+  dynamic "log_group" {
+    for_each = toset(local.lambda_functions)
+    content {
+      region = "us-east-1"
+      name   = "/aws/lambda/${log_group.value}"
+    }
+  }
+
+  log_group {
+    region     = "us-east-1"
+    name       = "/aws/lambda/healthcheck"
+    subscribed = false
+  }
+}
@@ -0,0 +1,28 @@
+# Link a source to an Azure tenant you've already connected, by account ID
+# azure_account_id is write-only - the API never returns it, so it isn't refreshed
+resource "logtail_source" "azure_existing" {
+  name     = "Azure staging"
+  platform = "azure"
+}
+
+resource "logtail_source_azure_tenant" "existing" {
+  source_id        = logtail_source.azure_existing.id
+  azure_account_id = "42"
+}
+
+# Connect a tenant with the client ID and client secret of an app registration
+# that has the Reader and Monitoring Contributor roles on the subscriptions
+resource "logtail_source" "azure" {
+  name     = "Azure production"
+  platform = "azure"
+}
+
+resource "logtail_source_azure_tenant" "azure" {
+  source_id           = logtail_source.azure.id
+  azure_tenant_id     = "00000000-0000-0000-0000-000000000000"
+  azure_client_id     = "11111111-1111-1111-1111-111111111111"
+  azure_client_secret = var.source_azure_client_secret
+
+  subscription_ids                   = ["22222222-2222-2222-2222-222222222222"]
+  auto_subscribe_diagnostic_settings = true
+}
@@ -0,0 +1,11 @@
+# Rotate the token of a source every 30 days, or right away by changing `keepers`,
+# accepting the previous token for a day while agents are redeployed
+resource "logtail_source_token_rotation" "this" {
+  source_id    = logtail_source.this.id
+  rotate_after = "720h"
+  grace_period = "24h"
+
+  keepers = {
+    reason = "initial"
+  }
+}
@@ -31,3 +31,9 @@ variable "source_custom_bucket_secret_access_key" {
   description = "Secret access key for the custom bucket"
   default     = null
 }
+
+variable "source_azure_client_secret" {
+  type        = string
+  description = "Client secret of the Azure app registration linked to a source"
+  default     = "example-rotate-me"
+}
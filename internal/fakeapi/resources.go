@@ -0,0 +1,436 @@
+package fakeapi
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DataRegions maps the data regions accepted on create to the cluster names the API returns.
+// Other values, e.g. private cluster names, are stored as given.
+var DataRegions = map[string]string{
+	"us_east":   "us-east-9",
+	"us_west":   "us-west-1",
+	"germany":   "eu-nbg-2",
+	"singapore": "ap-sin-1",
+}
+
+// DefaultDataRegion is the cluster of resources created without data_region.
+const DefaultDataRegion = "us-east-9"
+
+// collection describes how the fake serves a collection of resources.
+type collection struct {
+	// path is the collection path, with * for the IDs of the resources it's nested in.
+	path string
+	// typ is the JSON:API type of the resources.
+	typ string
+	// paginated collections are listed page by page, others in a single response.
+	paginated bool
+	// teamScoped resources get the team_id of the server.
+	teamScoped bool
+	// required attributes must be set on create.
+	required []string
+	// immutable attributes are rejected on update.
+	immutable []string
+	// hidden attributes are accepted but never returned, nested ones given as parent.key.
+	hidden []string
+	// createOnly are hidden attributes returned once, in the response to the create.
+	createOnly []string
+	// nestedIDs are lists of objects whose entries get an ID, e.g. the queries of a chart.
+	nestedIDs []string
+	// create fills in the attributes computed by the API.
+	create func(s *Server, it *item)
+	// update applies side effects of a PATCH before it's merged into the attributes.
+	update func(s *Server, it *item, patch map[string]interface{})
+}
+
+var collections = []*collection{
+	{
+		path: "/api/v2/sources", typ: "source", paginated: true, teamScoped: true,
+		required:  []string{"name", "platform"},
+		immutable: []string{"data_region", "custom_bucket"},
+		// Secrets and the credentials of the Azure tenant linkage are never returned.
+		hidden: []string{"custom_bucket.secret_access_key", "azure_account_id", "azure_tenant_id", "azure_client_id", "azure_client_secret"},
+		create: func(s *Server, it *item) {
+			setDefault(it, "token", randomToken())
+			setDefault(it, "table_name", tableName(it.attributes["name"]))
+			setDataRegion(it)
+			setDefault(it, "ingesting_host", fmt.Sprintf("s%s.%s.betterstackdata.com", it.id, it.attributes["data_region"]))
+			setDefault(it, "ingesting_paused", false)
+			setDefault(it, "logs_retention", 30)
+			setDefault(it, "metrics_retention", 30)
+			setDefault(it, "live_tail_pattern", "{level} {message}")
+			setDefault(it, "skip_ssl_verify", false)
+		},
+	},
+	{path: "/api/v2/sources/*/metrics", typ: "metric", paginated: true, required: []string{"name", "sql_expression"}},
+	{path: "/api/v2/sources/*/aws-log-group-subscriptions", typ: "aws_log_group_subscription", required: []string{"region", "name"}},
+	{path: "/api/v1/source-groups", typ: "source_group", paginated: true, required: []string{"name"}},
+	// The platform catalog is empty unless seeded, the provider then validates platforms against
+	// its own list.
+	{path: "/api/v2/platforms", typ: "platform", paginated: true, required: []string{"name", "kind"}},
+	{
+		path: "/api/v1/collectors", typ: "collector", paginated: true, teamScoped: true,
+		required:  []string{"name", "platform"},
+		immutable: []string{"data_region", "custom_bucket"},
+		hidden:    []string{"custom_bucket.secret_access_key", "databases"},
+		create: func(s *Server, it *item) {
+			setDefault(it, "secret", randomToken())
+			setDefault(it, "status", "waiting")
+			sourceID, _ := strconv.Atoi(s.newID())
+			setDefault(it, "source_id", sourceID)
+			setDataRegion(it)
+			setDefault(it, "ingesting_paused", false)
+			setDefault(it, "logs_retention", 30)
+			setDefault(it, "metrics_retention", 30)
+			setDefault(it, "live_tail_pattern", "{level} {message}")
+			setDefault(it, "hosts_count", 0)
+			setDefault(it, "hosts_up_count", 0)
+			setDefault(it, "pinged_at", nil)
+			setDefault(it, "user_vector_config", "")
+			configuration, _ := it.attributes["configuration"].(map[string]interface{})
+			if configuration == nil {
+				configuration = make(map[string]interface{})
+				it.attributes["configuration"] = configuration
+			}
+			for k, v := range map[string]interface{}{"logs_sample_rate": 100, "traces_sample_rate": 100} {
+				if _, ok := configuration[k]; !ok {
+					configuration[k] = v
+				}
+			}
+			setCollectorDatabases(s, it, it.attributes["databases"])
+		},
+		update: func(s *Server, it *item, patch map[string]interface{}) {
+			if databases, ok := patch["databases"]; ok {
+				setCollectorDatabases(s, it, databases)
+				delete(patch, "databases")
+			}
+			// The configuration is merged, settings missing from the PATCH are kept.
+			if configuration, ok := patch["configuration"].(map[string]interface{}); ok {
+				if existing, ok := it.attributes["configuration"].(map[string]interface{}); ok {
+					for k, v := range configuration {
+						existing[k] = v
+					}
+					delete(patch, "configuration")
+				}
+			}
+		},
+	},
+	{path: "/api/v1/collectors/*/targets", typ: "collector_target", hidden: []string{"password", "api_key"}, create: func(s *Server, it *item) {
+		setDefault(it, "enabled", true)
+		setDefault(it, "status", "pending")
+		setDefault(it, "container", nil)
+	}},
+	{
+		path: "/api/v1/connections", typ: "connection", paginated: true,
+		required:   []string{"client_type"},
+		hidden:     []string{"password"},
+		createOnly: []string{"password"},
+		create: func(s *Server, it *item) {
+			setDefault(it, "host", "eu-nbg-2-connect.betterstackdata.com")
+			setDefault(it, "port", 443)
+			setDefault(it, "username", "u"+randomToken()[:12])
+			setDefault(it, "password", randomToken())
+			setDefault(it, "created_by", map[string]interface{}{"email": "terraform@example.com"})
+			setDefault(it, "sample_query", "SELECT 1")
+			setDefault(it, "data_sources", []interface{}{})
+		},
+	},
+	{
+		path: "/api/v2/dashboards", typ: "dashboard", paginated: true, teamScoped: true,
+		required: []string{"name"},
+		create: func(s *Server, it *item) {
+			setDefault(it, "refresh_interval", 0)
+			setDefault(it, "date_range_from", "now-3h")
+			setDefault(it, "date_range_to", "now")
+			setDefault(it, "source_eligibility_sql", "")
+			setDefault(it, "variables", []interface{}{})
+		},
+	},
+	{path: "/api/v2/dashboards/templates", typ: "dashboard_template", paginated: true},
+	{path: "/api/v2/dashboards/*/charts", typ: "chart", nestedIDs: []string{"queries"}, create: func(s *Server, it *item) {
+		setDefault(it, "description", "")
+		setDefault(it, "x", 0)
+		setDefault(it, "y", 0)
+		setDefault(it, "w", 6)
+		setDefault(it, "h", 4)
+		setDefault(it, "settings", map[string]interface{}{})
+	}},
+	{path: "/api/v2/dashboards/*/sections", typ: "section", required: []string{"name"}, create: func(s *Server, it *item) {
+		setDefault(it, "y", 0)
+		setDefault(it, "collapsed", false)
+		setDefault(it, "explanation", "")
+	}},
+	{path: "/api/v2/dashboards/*/charts/*/alerts", typ: "alert", required: []string{"name"}, create: setAlertDefaults},
+	{path: "/api/v2/dashboard-groups", typ: "dashboard_group", paginated: true, required: []string{"name"}},
+	{path: "/api/v2/explorations", typ: "exploration", paginated: true, required: []string{"name"}, nestedIDs: []string{"queries"}, create: func(s *Server, it *item) {
+		setDefault(it, "date_range_from", "now-3h")
+		setDefault(it, "date_range_to", "now")
+	}},
+	{path: "/api/v2/explorations/*/alerts", typ: "alert", required: []string{"name"}, create: setAlertDefaults},
+	{path: "/api/v2/exploration-groups", typ: "exploration_group", paginated: true, required: []string{"name"}},
+	{
+		path: "/api/v2/applications", typ: "application", paginated: true, teamScoped: true,
+		required:  []string{"name", "platform"},
+		immutable: []string{"data_region", "custom_bucket"},
+		// Secrets and the credentials of the Azure tenant linkage are never returned.
+		hidden: []string{"custom_bucket.secret_access_key", "azure_account_id", "azure_tenant_id", "azure_client_id", "azure_client_secret"},
+		create: func(s *Server, it *item) {
+			setDefault(it, "token", randomToken())
+			setDefault(it, "js_tag_token", randomToken())
+			setDefault(it, "table_name", tableName(it.attributes["name"]))
+			setDataRegion(it)
+			setDefault(it, "ingesting_host", fmt.Sprintf("s%s.%s.betterstackdata.com", it.id, it.attributes["data_region"]))
+			setDefault(it, "ingesting_paused", false)
+			setDefault(it, "errors_retention", 90)
+		},
+	},
+	{path: "/api/v1/application-groups", typ: "application_group", paginated: true, required: []string{"name"}},
+}
+
+// setAlertDefaults fills in the attributes the API computes for dashboard and exploration alerts.
+func setAlertDefaults(s *Server, it *item) {
+	setDefault(it, "series_names", []interface{}{})
+	setDefault(it, "series_names_except", []interface{}{})
+	setDefault(it, "source_platforms", []interface{}{})
+	setDefault(it, "source_mode", "source_variable")
+	setDefault(it, "on_missing_data", "treat_as_zero")
+	setDefault(it, "paused", false)
+	setDefault(it, "paused_reason", nil)
+	setDefault(it, "incident_per_series", false)
+}
+
+// matchCollection returns the collection served at path, e.g. /api/v2/dashboards/1/charts.
+func matchCollection(path string) (*collection, bool) {
+	segments := strings.Split(path, "/")
+	for _, c := range collections {
+		pattern := strings.Split(c.path, "/")
+		if len(pattern) != len(segments) {
+			continue
+		}
+		match := true
+		for i, p := range pattern {
+			if p != "*" && p != segments[i] || p == "*" && segments[i] == "" {
+				match = false
+				break
+			}
+		}
+		if match {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// validate returns the errors of a create or update request, like the 422 response of the API.
+func (c *collection) validate(body map[string]interface{}, create bool) map[string]interface{} {
+	errs := make(map[string]interface{})
+	for _, k := range c.required {
+		v, ok := body[k]
+		if create && !ok || ok && (v == nil || v == "") {
+			errs[k] = []string{"can't be blank"}
+		}
+	}
+	return errs
+}
+
+// specialHandler serves an endpoint that isn't plain CRUD of a collection.
+type specialHandler func(s *Server, w http.ResponseWriter, r *http.Request, vars []string, body map[string]interface{})
+
+var specials = []struct {
+	method  string
+	path    string
+	handler specialHandler
+}{
+	{http.MethodPost, "/api/v2/dashboards/import", importDashboard},
+	{http.MethodGet, "/api/v2/dashboards/*/export", exportDashboard},
+	{http.MethodGet, "/api/v1/collectors/*/databases", listCollectorDatabases},
+	{http.MethodPost, "/api/v2/sources/*/token-rotations", rotateSourceToken},
+}
+
+func matchSpecial(method, path string) (specialHandler, []string, bool) {
+	segments := strings.Split(path, "/")
+	for _, sp := range specials {
+		pattern := strings.Split(sp.path, "/")
+		if sp.method != method || len(pattern) != len(segments) {
+			continue
+		}
+		var vars []string
+		match := true
+		for i, p := range pattern {
+			if p == "*" {
+				vars = append(vars, segments[i])
+			} else if p != segments[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return sp.handler, vars, true
+		}
+	}
+	return nil, nil, false
+}
+
+// importDashboard creates a dashboard from the JSON of an export. The data isn't part of the
+// dashboard attributes, it's returned by the export endpoint.
+func importDashboard(s *Server, w http.ResponseWriter, r *http.Request, _ []string, body map[string]interface{}) {
+	data, _ := body["data"].(map[string]interface{})
+	if data == nil {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{"errors": map[string]interface{}{"data": []string{"can't be blank"}}})
+		return
+	}
+	delete(body, "data")
+	if _, ok := body["name"]; !ok {
+		body["name"] = data["name"]
+	}
+	c, _ := matchCollection("/api/v2/dashboards")
+	created := len(s.lists[c.path])
+	s.create(w, c, c.path, body)
+	if ids := s.lists[c.path]; len(ids) > created {
+		s.items[c.path+"/"+ids[len(ids)-1]].hidden["data"] = data
+	}
+}
+
+// exportDashboard returns the data of an imported dashboard, or of a template seeded with a data
+// attribute.
+func exportDashboard(s *Server, w http.ResponseWriter, r *http.Request, vars []string, _ map[string]interface{}) {
+	it, ok := s.items["/api/v2/dashboards/"+vars[0]]
+	if !ok {
+		// Templates are exported like dashboards.
+		it, ok = s.items["/api/v2/dashboards/templates/"+vars[0]]
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "Resource not found")
+		return
+	}
+	data, _ := it.hidden["data"].(map[string]interface{})
+	if data == nil {
+		data, _ = it.attributes["data"].(map[string]interface{})
+	}
+	if data == nil {
+		data = map[string]interface{}{"name": it.attributes["name"], "charts": []interface{}{}, "sections": []interface{}{}}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+}
+
+// rotateSourceToken replaces the token of a source. The previous token stays valid for
+// grace_period seconds.
+func rotateSourceToken(s *Server, w http.ResponseWriter, r *http.Request, vars []string, body map[string]interface{}) {
+	source, ok := s.items["/api/v2/sources/"+vars[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Resource not found")
+		return
+	}
+	var gracePeriod int64
+	if v, ok := body["grace_period"].(json.Number); ok {
+		gracePeriod, _ = v.Int64()
+	}
+	now := s.now().UTC()
+	attributes := map[string]interface{}{
+		"grace_period":              gracePeriod,
+		"token":                     randomToken(),
+		"previous_token":            source.attributes["token"],
+		"previous_token_expires_at": now.Add(time.Duration(gracePeriod) * time.Second).Format(time.RFC3339),
+		"created_at":                now.Format(time.RFC3339),
+	}
+	source.attributes["token"] = attributes["token"]
+	source.attributes["updated_at"] = attributes["created_at"]
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"data": map[string]interface{}{"id": s.newID(), "type": "source_token_rotation", "attributes": attributes}})
+}
+
+// setCollectorDatabases stores the databases of a collector like the API: new entries get an ID,
+// entries with _destroy are removed and passwords are never returned. The collector itself only
+// returns databases_count, the databases are listed by their own endpoint.
+func setCollectorDatabases(s *Server, it *item, v interface{}) {
+	entries, _ := v.([]interface{})
+	existing, _ := it.hidden["databases"].([]interface{})
+	byID := make(map[string]map[string]interface{})
+	for _, e := range existing {
+		m := e.(map[string]interface{})
+		byID[fmt.Sprint(m["id"])] = m
+	}
+
+	var databases []interface{}
+	for _, e := range entries {
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if destroy, _ := m["_destroy"].(bool); destroy {
+			continue
+		}
+		delete(m, "password")
+		if m["id"] == nil {
+			id, _ := strconv.Atoi(s.newID())
+			m["id"] = id
+		} else if old, ok := byID[fmt.Sprint(m["id"])]; ok {
+			for k, v := range m {
+				old[k] = v
+			}
+			m = old
+		}
+		databases = append(databases, m)
+	}
+	it.hidden["databases"] = databases
+	delete(it.attributes, "databases")
+	it.attributes["databases_count"] = len(databases)
+}
+
+func listCollectorDatabases(s *Server, w http.ResponseWriter, r *http.Request, vars []string, _ map[string]interface{}) {
+	it, ok := s.items["/api/v1/collectors/"+vars[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Resource not found")
+		return
+	}
+	databases, _ := it.hidden["databases"].([]interface{})
+	data := []interface{}{}
+	for _, db := range databases {
+		attributes := deepCopy(db).(map[string]interface{})
+		id := attributes["id"]
+		delete(attributes, "id")
+		data = append(data, map[string]interface{}{"id": fmt.Sprint(id), "type": "collector_database", "attributes": attributes})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+}
+
+func setDefault(it *item, key string, value interface{}) {
+	if _, ok := it.attributes[key]; !ok {
+		it.attributes[key] = value
+	}
+}
+
+// setDataRegion renames the requested data region to its cluster name.
+func setDataRegion(it *item) {
+	region, _ := it.attributes["data_region"].(string)
+	switch {
+	case region == "":
+		it.attributes["data_region"] = DefaultDataRegion
+	case DataRegions[region] != "":
+		it.attributes["data_region"] = DataRegions[region]
+	}
+}
+
+// tableName derives the ClickHouse table name of a source from its name.
+func tableName(name interface{}) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(fmt.Sprint(name)) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+func randomToken() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
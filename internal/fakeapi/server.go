@@ -0,0 +1,453 @@
+// Package fakeapi is an in-memory fake of the Better Stack Telemetry and Errors APIs, for running
+// the full resource.Test lifecycle - create, empty plan, import, update and destroy - offline.
+//
+// The fake keeps every created resource and answers the endpoints the provider uses like the real
+// API does: resources are listed page by page, VRL programs are stored with a trailing "\n.",
+// data regions are renamed to cluster names, secrets are accepted but never returned, and the
+// attributes the API computes (tokens, hosts, timestamps, ...) are filled in.
+//
+//	api := fakeapi.New(t)
+//	resource.Test(t, resource.TestCase{
+//		ProviderFactories: map[string]func() (*schema.Provider, error){
+//			"logtail": func() (*schema.Provider, error) { return provider.New(provider.WithURL(api.URL)), nil },
+//		},
+//		...
+//	})
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Token is the API token the fake accepts by default, see Server.Token.
+const Token = "foo"
+
+// DefaultPerPage is the page size of listed collections, small enough for tests to cross pages.
+const DefaultPerPage = 50
+
+// Server is a fake API server. Both the Telemetry and the Errors API are served on URL.
+type Server struct {
+	*httptest.Server
+
+	// Token is the Bearer token requests must send, defaults to Token. Empty accepts any.
+	Token string
+	// PerPage is the page size of listed collections, defaults to DefaultPerPage.
+	PerPage int
+	// TeamID is the team_id of created resources.
+	TeamID int
+
+	t        testing.TB
+	mu       sync.Mutex
+	nextID   int
+	items    map[string]*item    // by item path, e.g. /api/v2/sources/1
+	lists    map[string][]string // item IDs of a collection path in creation order
+	requests []string
+	now      func() time.Time
+}
+
+// item is a stored resource.
+type item struct {
+	id         string
+	attributes map[string]interface{}
+	// hidden holds the attributes the API accepts but never returns, e.g. the data of an
+	// imported dashboard, returned by its export endpoint only.
+	hidden map[string]interface{}
+}
+
+// New starts a fake API server, closed when the test finishes. Unexpected requests fail the test.
+func New(t testing.TB) *Server {
+	s := &Server{
+		Token:   Token,
+		PerPage: DefaultPerPage,
+		TeamID:  123456,
+		t:       t,
+		items:   make(map[string]*item),
+		lists:   make(map[string][]string),
+		now:     time.Now,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Requests returns the requests received so far, as "METHOD /path?query".
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// Attributes returns a copy of the stored attributes of the resource at path, e.g.
+// /api/v2/sources/1, hidden ones included, or nil if there is none.
+func (s *Server) Attributes(path string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	it, ok := s.items[path]
+	if !ok {
+		return nil
+	}
+	out := deepCopy(it.attributes).(map[string]interface{})
+	for k, v := range it.hidden {
+		out[k] = deepCopy(v)
+	}
+	return out
+}
+
+// Paths returns the sorted paths of the stored resources, e.g. to check that a test destroyed
+// everything it created.
+func (s *Server) Paths() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	paths := make([]string, 0, len(s.items))
+	for p := range s.items {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Seed stores a resource in the collection at path as if it was created through the API, without
+// validation, and returns its ID. It's meant for resources created outside of Terraform, e.g. a
+// source looked up by a data source, or for read-only collections like dashboard templates.
+func (s *Server) Seed(path string, attributes map[string]interface{}) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	it := &item{id: s.newID(), attributes: deepCopy(attributes).(map[string]interface{}), hidden: make(map[string]interface{})}
+	s.store(path, it)
+	return it.id
+}
+
+func (s *Server) newID() string {
+	s.nextID++
+	return strconv.Itoa(s.nextID)
+}
+
+func (s *Server) store(collectionPath string, it *item) {
+	s.items[collectionPath+"/"+it.id] = it
+	s.lists[collectionPath] = append(s.lists[collectionPath], it.id)
+}
+
+// delete removes the resource at path and everything nested under it, e.g. the charts of a
+// dashboard.
+func (s *Server) delete(collectionPath, id string) {
+	itemPath := collectionPath + "/" + id
+	delete(s.items, itemPath)
+	ids := s.lists[collectionPath]
+	for i, v := range ids {
+		if v == id {
+			s.lists[collectionPath] = append(ids[:i:i], ids[i+1:]...)
+			break
+		}
+	}
+	for p := range s.items {
+		if strings.HasPrefix(p, itemPath+"/") {
+			delete(s.items, p)
+		}
+	}
+	for p := range s.lists {
+		if strings.HasPrefix(p, itemPath+"/") {
+			delete(s.lists, p)
+		}
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.RequestURI())
+
+	if s.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.Token {
+		writeError(w, http.StatusUnauthorized, "Invalid Team API token.")
+		return
+	}
+
+	var body map[string]interface{}
+	if r.Method == http.MethodPost || r.Method == http.MethodPatch {
+		decoder := json.NewDecoder(r.Body)
+		decoder.UseNumber()
+		if err := decoder.Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	if h, vars, ok := matchSpecial(r.Method, path); ok {
+		h(s, w, r, vars, body)
+		return
+	}
+	if c, ok := matchCollection(path); ok {
+		if !s.parentExists(path) {
+			writeError(w, http.StatusNotFound, "Resource not found")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			s.list(w, r, c, path)
+			return
+		case http.MethodPost:
+			s.create(w, c, path, body)
+			return
+		}
+	} else if i := strings.LastIndex(path, "/"); i > 0 {
+		if c, ok := matchCollection(path[:i]); ok {
+			collectionPath, id := path[:i], path[i+1:]
+			it, exists := s.items[path]
+			if !exists {
+				writeError(w, http.StatusNotFound, "Resource not found")
+				return
+			}
+			switch r.Method {
+			case http.MethodGet:
+				writeJSON(w, http.StatusOK, map[string]interface{}{"data": s.render(c, it)})
+				return
+			case http.MethodPatch:
+				s.update(w, c, it, body)
+				return
+			case http.MethodDelete:
+				s.delete(collectionPath, id)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+	}
+
+	s.t.Errorf("fakeapi: unexpected %s %s", r.Method, r.URL.RequestURI())
+	writeError(w, http.StatusNotFound, "Not found")
+}
+
+// parentExists reports whether the resource a nested collection belongs to exists, e.g. the
+// dashboard of /api/v2/dashboards/1/charts.
+func (s *Server) parentExists(collectionPath string) bool {
+	i := strings.LastIndex(collectionPath, "/")
+	parent := collectionPath[:i]
+	if _, ok := matchCollection(parent[:strings.LastIndex(parent, "/")]); !ok {
+		return true
+	}
+	_, ok := s.items[parent]
+	return ok
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, c *collection, path string) {
+	query := r.URL.Query()
+	var data []interface{}
+	for _, id := range s.lists[path] {
+		it := s.items[path+"/"+id]
+		if matchesFilters(it, query) {
+			data = append(data, s.render(c, it))
+		}
+	}
+	if data == nil {
+		data = []interface{}{}
+	}
+	if !c.paginated {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+		return
+	}
+
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	perPage := s.PerPage
+	if perPage < 1 {
+		perPage = DefaultPerPage
+	}
+	last := (len(data) + perPage - 1) / perPage
+	if last < 1 {
+		last = 1
+	}
+	pageURL := func(p int) interface{} {
+		if p < 1 || p > last {
+			return nil
+		}
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		q.Set("page", strconv.Itoa(p))
+		return s.URL + path + "?" + q.Encode()
+	}
+	from, to := min((page-1)*perPage, len(data)), min(page*perPage, len(data))
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": data[from:to],
+		"pagination": map[string]interface{}{
+			"first": pageURL(1),
+			"last":  pageURL(last),
+			"prev":  pageURL(page - 1),
+			"next":  pageURL(page + 1),
+		},
+	})
+}
+
+// matchesFilters reports whether it matches the query parameters other than page, e.g.
+// ?name=Production, compared as strings.
+func matchesFilters(it *item, query url.Values) bool {
+	for k, v := range query {
+		if k == "page" || k == "per_page" {
+			continue
+		}
+		if fmt.Sprint(it.attributes[k]) != v[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) create(w http.ResponseWriter, c *collection, path string, body map[string]interface{}) {
+	if errs := c.validate(body, true); len(errs) > 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{"errors": errs})
+		return
+	}
+	it := &item{id: s.newID(), attributes: body, hidden: make(map[string]interface{})}
+	now := s.now().UTC().Format(time.RFC3339)
+	it.attributes["created_at"] = now
+	it.attributes["updated_at"] = now
+	if _, ok := it.attributes["team_id"]; !ok && c.teamScoped {
+		it.attributes["team_id"] = s.TeamID
+	}
+	delete(it.attributes, "team_name")
+	if c.create != nil {
+		c.create(s, it)
+	}
+	s.normalize(c, it)
+	s.store(path, it)
+
+	data := s.render(c, it)
+	// Secrets returned by create only, e.g. the password of a connection.
+	for _, k := range c.createOnly {
+		if v, ok := it.hidden[k]; ok {
+			data["attributes"].(map[string]interface{})[k] = v
+		}
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"data": data})
+}
+
+func (s *Server) update(w http.ResponseWriter, c *collection, it *item, patch map[string]interface{}) {
+	if errs := c.validate(patch, false); len(errs) > 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{"errors": errs})
+		return
+	}
+	for _, k := range c.immutable {
+		if _, ok := patch[k]; ok {
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{"errors": map[string]interface{}{k: []string{"can't be changed"}}})
+			return
+		}
+	}
+	delete(patch, "team_name")
+	if c.update != nil {
+		c.update(s, it, patch)
+	}
+	for k, v := range patch {
+		it.attributes[k] = v
+	}
+	it.attributes["updated_at"] = s.now().UTC().Format(time.RFC3339)
+	s.normalize(c, it)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": s.render(c, it)})
+}
+
+// normalize applies the normalization every resource gets: VRL programs are stored with a
+// trailing "\n.", secrets are moved out of the returned attributes, and nested entries like chart
+// queries get an ID.
+func (s *Server) normalize(c *collection, it *item) {
+	normalizeVRL(it.attributes)
+	for _, k := range c.hidden {
+		hide(it, k)
+	}
+	for _, k := range c.nestedIDs {
+		entries, _ := it.attributes[k].([]interface{})
+		for _, e := range entries {
+			if m, ok := e.(map[string]interface{}); ok && m["id"] == nil {
+				id, _ := strconv.Atoi(s.newID())
+				m["id"] = id
+			}
+		}
+	}
+}
+
+// hide moves the attribute at key out of the returned attributes. Keys of nested attributes are
+// given as parent.key, and apply to every element of a list.
+func hide(it *item, key string) {
+	parent, child, nested := strings.Cut(key, ".")
+	if !nested {
+		if v, ok := it.attributes[key]; ok {
+			it.hidden[key] = v
+			delete(it.attributes, key)
+		}
+		return
+	}
+	switch v := it.attributes[parent].(type) {
+	case map[string]interface{}:
+		delete(v, child)
+	case []interface{}:
+		for _, e := range v {
+			if m, ok := e.(map[string]interface{}); ok {
+				delete(m, child)
+			}
+		}
+	}
+}
+
+// normalizeVRL appends "\n." to the VRL programs in attributes, like the API stores them.
+func normalizeVRL(attributes map[string]interface{}) {
+	for k, v := range attributes {
+		switch v := v.(type) {
+		case string:
+			if strings.Contains(k, "vrl_transformation") && strings.TrimSpace(v) != "" && !strings.HasSuffix(v, "\n.") {
+				attributes[k] = v + "\n."
+			}
+		case map[string]interface{}:
+			normalizeVRL(v)
+		}
+	}
+}
+
+func (s *Server) render(c *collection, it *item) map[string]interface{} {
+	data := map[string]interface{}{
+		"id":         it.id,
+		"attributes": deepCopy(it.attributes),
+	}
+	if c.typ != "" {
+		data["type"] = c.typ
+	}
+	return data
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{"errors": message})
+}
+
+func deepCopy(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			out[k] = deepCopy(e)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			out[i] = deepCopy(e)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,235 @@
+package fakeapi_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
+	"github.com/betterstackhq/terraform-provider-logtail/internal/fakeapi"
+)
+
+func newClient(t *testing.T, api *fakeapi.Server) *betterstack.Client {
+	t.Helper()
+	c, err := betterstack.New(betterstack.Config{BaseURL: api.URL, Token: fakeapi.Token, RetryMax: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestSourceLifecycle(t *testing.T) {
+	api := fakeapi.New(t)
+	c := newClient(t, api)
+	ctx := context.Background()
+
+	created, err := c.Sources().Create(ctx, &betterstack.Source{
+		Name:                  ptr("Production API"),
+		Platform:              ptr("kubernetes"),
+		DataRegion:            ptr("germany"),
+		VrlTransformationLogs: ptr(".env = \"production\""),
+		CustomBucket: &betterstack.SourceCustomBucket{
+			Name:            ptr("logs"),
+			Endpoint:        ptr("https://s3.example.com"),
+			AccessKeyID:     ptr("AKIA"),
+			SecretAccessKey: ptr("secret"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := created.Attributes
+	if *got.DataRegion != "eu-nbg-2" {
+		t.Errorf("got data_region %q, want the cluster name", *got.DataRegion)
+	}
+	if *got.VrlTransformationLogs != ".env = \"production\"\n." {
+		t.Errorf("got vrl_transformation_logs %q, want a trailing \\n.", *got.VrlTransformationLogs)
+	}
+	if got.Token == nil || *got.Token == "" || *got.TableName != "production_api" || *got.IngestingHost != fmt.Sprintf("s%s.eu-nbg-2.betterstackdata.com", created.ID) {
+		t.Errorf("got computed attributes token %v, table_name %v, ingesting_host %v", got.Token, *got.TableName, *got.IngestingHost)
+	}
+	if got.CustomBucket == nil || got.CustomBucket.SecretAccessKey != nil {
+		t.Errorf("got custom_bucket %+v, want it without the secret", got.CustomBucket)
+	}
+	if stored := api.Attributes("/api/v2/sources/" + created.ID); stored["custom_bucket"] == nil {
+		t.Errorf("custom_bucket wasn't stored")
+	}
+
+	if err := c.Sources().Update(ctx, created.ID, &betterstack.Source{LogsRetention: ptr(60)}); err != nil {
+		t.Fatal(err)
+	}
+	read, err := c.Sources().Get(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *read.Attributes.LogsRetention != 60 || *read.Attributes.Name != "Production API" {
+		t.Errorf("got %+v after update", read.Attributes)
+	}
+	if err := c.Sources().Update(ctx, created.ID, &betterstack.Source{DataRegion: ptr("us_east")}); err == nil || !strings.Contains(err.Error(), "can't be changed") {
+		t.Errorf("got error %v updating data_region, want it rejected", err)
+	}
+
+	if err := c.Sources().Delete(ctx, created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Sources().Get(ctx, created.ID); !betterstack.IsNotFound(err) {
+		t.Errorf("got error %v reading a deleted source, want not found", err)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	api := fakeapi.New(t)
+	_, err := newClient(t, api).Sources().Create(context.Background(), &betterstack.Source{Platform: ptr("kubernetes")})
+	if err == nil || !strings.Contains(err.Error(), "can't be blank") {
+		t.Errorf("got error %v, want name to be required", err)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	api := fakeapi.New(t)
+	api.PerPage = 2
+	for i := 0; i < 5; i++ {
+		api.Seed("/api/v1/source-groups", map[string]interface{}{"name": fmt.Sprintf("group %d", i)})
+	}
+
+	var names []string
+	for item, err := range newClient(t, api).SourceGroups().List(context.Background()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, *item.Attributes.Name)
+	}
+	if got, want := strings.Join(names, ","), "group 0,group 1,group 2,group 3,group 4"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if n := len(api.Requests()); n != 3 {
+		t.Errorf("got %d requests, want 3 pages: %v", n, api.Requests())
+	}
+}
+
+func TestNestedResources(t *testing.T) {
+	api := fakeapi.New(t)
+	c := newClient(t, api)
+	ctx := context.Background()
+
+	dashboard, err := c.Dashboards().Create(ctx, &betterstack.Dashboard{Name: ptr("Overview")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chart, err := c.DashboardCharts(dashboard.ID).Create(ctx, &betterstack.DashboardChart{
+		ChartType: ptr("line_chart"),
+		Name:      ptr("Requests"),
+		Queries:   []betterstack.DashboardChartQuery{{QueryType: ptr("sql_expression")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q := chart.Attributes.Queries; len(q) != 1 || q[0].ID == nil {
+		t.Errorf("got queries %+v, want them to get an ID", q)
+	}
+	if *chart.Attributes.W != 6 || *chart.Attributes.H != 4 {
+		t.Errorf("got size %dx%d, want the default", *chart.Attributes.W, *chart.Attributes.H)
+	}
+
+	if err := c.Dashboards().Delete(ctx, dashboard.ID); err != nil {
+		t.Fatal(err)
+	}
+	if api.Attributes(c.DashboardCharts(dashboard.ID).ItemPath(chart.ID)) != nil {
+		t.Errorf("the chart of a deleted dashboard still exists")
+	}
+	if paths := api.Paths(); len(paths) != 0 {
+		t.Errorf("got resources %v after deleting the dashboard, want none", paths)
+	}
+	if _, err := c.DashboardCharts(dashboard.ID).Create(ctx, &betterstack.DashboardChart{Name: ptr("Errors")}); !betterstack.IsNotFound(err) {
+		t.Errorf("got error %v creating a chart of a deleted dashboard, want not found", err)
+	}
+}
+
+func TestCollectorDatabases(t *testing.T) {
+	api := fakeapi.New(t)
+	c := newClient(t, api)
+	ctx := context.Background()
+
+	created, err := c.Collectors().Create(ctx, &betterstack.Collector{
+		Name:     ptr("Cluster"),
+		Platform: ptr("kubernetes"),
+		Databases: &[]betterstack.CollectorDatabase{
+			{ServiceType: ptr("postgresql"), Host: ptr("db"), Password: ptr("secret")},
+			{ServiceType: ptr("redis"), Host: ptr("cache")},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Attributes.Databases != nil || *created.Attributes.DatabasesCount != 2 {
+		t.Errorf("got databases %v, databases_count %v, want the count only", created.Attributes.Databases, created.Attributes.DatabasesCount)
+	}
+
+	path := c.Collectors().ItemPath(created.ID) + "/databases"
+	var out struct {
+		Data []betterstack.Item[betterstack.CollectorDatabase] `json:"data"`
+	}
+	if err := c.Read(ctx, c.TelemetryBaseURL(), path, &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Data) != 2 || out.Data[0].Attributes.Password != nil {
+		t.Fatalf("got databases %+v, want both without passwords", out.Data)
+	}
+
+	var id int
+	_, _ = fmt.Sscan(out.Data[0].ID, &id)
+	if err := c.Collectors().Update(ctx, created.ID, &betterstack.Collector{
+		Databases: &[]betterstack.CollectorDatabase{{ID: &id, Destroy: ptr(true)}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Read(ctx, c.TelemetryBaseURL(), path, &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Data) != 0 {
+		t.Errorf("got databases %+v, want them replaced", out.Data)
+	}
+}
+
+func TestConnectionPasswordReturnedOnCreate(t *testing.T) {
+	api := fakeapi.New(t)
+	c := newClient(t, api)
+	ctx := context.Background()
+
+	created, err := c.Connections().Create(ctx, &betterstack.Connection{ClientType: ptr("clickhouse")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Attributes.Password == nil {
+		t.Errorf("got no password on create")
+	}
+	read, err := c.Connections().Get(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Attributes.Password != nil {
+		t.Errorf("got password %q on read, want it hidden", *read.Attributes.Password)
+	}
+}
+
+func TestUnauthorized(t *testing.T) {
+	api := fakeapi.New(t)
+	c, err := betterstack.New(betterstack.Config{BaseURL: api.URL, Token: "bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.Get(context.Background(), "/api/v2/sources")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("got status %d, want 401", resp.StatusCode)
+	}
+}
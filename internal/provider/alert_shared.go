@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -374,53 +375,27 @@ var alertSchema = map[string]*schema.Schema{
 	},
 }
 
-// alertMetadataValue represents a Better Stack alert metadata value, which may
-// be either a plain string or a JSON array of strings on the wire. The API
-// always returns arrays on reads but still accepts both shapes on writes.
-type alertMetadataValue struct {
-	isArray bool
-	str     string
-	arr     []string
-}
-
-func (v *alertMetadataValue) UnmarshalJSON(data []byte) error {
-	var arr []string
-	if err := json.Unmarshal(data, &arr); err == nil {
-		v.isArray = true
-		v.arr = arr
-		return nil
-	}
-	var s string
-	if err := json.Unmarshal(data, &s); err == nil {
-		v.isArray = false
-		v.str = s
-		return nil
-	}
-	return fmt.Errorf("metadata value must be string or []string, got %s", string(data))
-}
-
-func (v alertMetadataValue) MarshalJSON() ([]byte, error) {
-	if v.isArray {
-		if v.arr == nil {
-			return json.Marshal([]string{})
-		}
-		return json.Marshal(v.arr)
-	}
-	return json.Marshal(v.str)
-}
+type (
+	alert                        = betterstack.Alert
+	alertCondition               = betterstack.AlertCondition
+	alertEscalationTarget        = betterstack.AlertEscalationTarget
+	alertEscalationTargetWrapper = betterstack.AlertEscalationTargetWrapper
+	alertMetadataValue           = betterstack.AlertMetadataValue
+)
 
-// terraformValue normalizes a metadata value back to its Terraform schema
-// representation (TypeMap of TypeString). Single-element arrays become plain
-// strings; multi-element arrays are emitted as compact JSON, matching the
+// alertMetadataTerraformValue normalizes a metadata value back to its Terraform
+// schema representation (TypeMap of TypeString). Single-element arrays become
+// plain strings; multi-element arrays are emitted as compact JSON, matching the
 // shape produced by HCL's jsonencode().
-func (v alertMetadataValue) terraformValue() string {
-	if !v.isArray {
-		return v.str
+func alertMetadataTerraformValue(v alertMetadataValue) string {
+	if !v.IsArray() {
+		return v.String()
 	}
-	if len(v.arr) == 1 {
-		return v.arr[0]
+	arr := v.Strings()
+	if len(arr) == 1 {
+		return arr[0]
 	}
-	buf, err := json.Marshal(v.arr)
+	buf, err := json.Marshal(arr)
 	if err != nil {
 		return ""
 	}
@@ -434,103 +409,13 @@ func metadataValueFromTerraform(s string) alertMetadataValue {
 	if strings.HasPrefix(s, "[") {
 		var arr []string
 		if err := json.Unmarshal([]byte(s), &arr); err == nil {
-			return alertMetadataValue{isArray: true, arr: arr}
+			return betterstack.AlertMetadataStrings(arr)
 		}
 	}
-	return alertMetadataValue{isArray: false, str: s}
-}
-
-// alertEscalationTarget handles polymorphic response - can be null, string "current_team", or object
-type alertEscalationTarget struct {
-	TeamID     *int    `json:"team_id,omitempty"`
-	TeamName   *string `json:"team_name,omitempty"`
-	PolicyID   *int    `json:"policy_id,omitempty"`
-	PolicyName *string `json:"policy_name,omitempty"`
-}
-
-// alertEscalationTargetWrapper handles the polymorphic escalation_target field
-type alertEscalationTargetWrapper struct {
-	Value *alertEscalationTarget
+	return betterstack.AlertMetadataString(s)
 }
 
-func (w *alertEscalationTargetWrapper) UnmarshalJSON(data []byte) error {
-	// Try to unmarshal as string first (e.g., "current_team")
-	var str string
-	if err := json.Unmarshal(data, &str); err == nil {
-		// String value like "current_team" means no explicit target set
-		w.Value = nil
-		return nil
-	}
-
-	// Try to unmarshal as object
-	var target alertEscalationTarget
-	if err := json.Unmarshal(data, &target); err != nil {
-		return err
-	}
-	w.Value = &target
-	return nil
-}
-
-func (w alertEscalationTargetWrapper) MarshalJSON() ([]byte, error) {
-	if w.Value == nil {
-		return json.Marshal(nil)
-	}
-	return json.Marshal(w.Value)
-}
-
-type alert struct {
-	Name                     *string                       `json:"name,omitempty"`
-	AlertType                *string                       `json:"alert_type,omitempty"`
-	Operator                 *string                       `json:"operator,omitempty"`
-	Value                    *float64                      `json:"value,omitempty"`
-	StringValue              *string                       `json:"string_value,omitempty"`
-	QueryPeriod              *int                          `json:"query_period,omitempty"`
-	ConfirmationPeriod       *int                          `json:"confirmation_period,omitempty"`
-	RecoveryPeriod           *NullableInt                  `json:"recovery_period,omitempty"`
-	AggregationInterval      *int                          `json:"aggregation_interval,omitempty"`
-	CheckPeriod              *int                          `json:"check_period,omitempty"`
-	SeriesNames              *[]string                     `json:"series_names,omitempty"`
-	SeriesNamesExcept        *[]string                     `json:"series_names_except,omitempty"`
-	OnMissingData            *string                       `json:"on_missing_data,omitempty"`
-	SourceVariable           *string                       `json:"source_variable,omitempty"`
-	SourceMode               *string                       `json:"source_mode,omitempty"`
-	SourcePlatforms          []string                      `json:"source_platforms,omitempty"`
-	IncidentCause            *string                       `json:"incident_cause,omitempty"`
-	IncidentPerSeries        *bool                         `json:"incident_per_series,omitempty"`
-	Paused                   *bool                         `json:"paused,omitempty"`
-	PausedReason             *string                       `json:"paused_reason,omitempty"`
-	Call                     *bool                         `json:"call,omitempty"`
-	SMS                      *bool                         `json:"sms,omitempty"`
-	Email                    *bool                         `json:"email,omitempty"`
-	Push                     *bool                         `json:"push,omitempty"`
-	CriticalAlert            *bool                         `json:"critical_alert,omitempty"`
-	AnomalySensitivity       *float64                      `json:"anomaly_sensitivity,omitempty"`
-	AnomalyTrigger           *string                       `json:"anomaly_trigger,omitempty"`
-	AnomalyTrainingRangeDays *int                          `json:"anomaly_training_range_days,omitempty"`
-	AdditionalConditions     *[]alertCondition             `json:"additional_conditions,omitempty"`
-	EscalationTarget         alertEscalationTargetWrapper  `json:"escalation_target,omitempty"`
-	Metadata                 map[string]alertMetadataValue `json:"metadata,omitempty"`
-	CreatedAt                *string                       `json:"created_at,omitempty"`
-	UpdatedAt                *string                       `json:"updated_at,omitempty"`
-}
-
-type alertHTTPResponse struct {
-	Data struct {
-		ID         string `json:"id"`
-		Attributes alert  `json:"attributes"`
-	} `json:"data"`
-}
-
-// alertCondition is one additional alert condition; the main condition lives
-// in the alert's own alert_type/operator/value fields.
-type alertCondition struct {
-	AlertType         *string   `json:"alert_type,omitempty"`
-	Operator          *string   `json:"operator,omitempty"`
-	Value             *float64  `json:"value,omitempty"`
-	StringValue       *string   `json:"string_value,omitempty"`
-	SeriesNames       *[]string `json:"series_names,omitempty"`
-	SeriesNamesExcept *[]string `json:"series_names_except,omitempty"`
-}
+type alertHTTPResponse = betterstack.Response[alert]
 
 // conditionsFromRawConfig converts the raw config list of additional_conditions
 // into wire objects, keeping attributes the user never wrote out of the payload.
@@ -977,7 +862,7 @@ func alertCopyAttrs(d *schema.ResourceData, in *alert) diag.Diagnostics {
 	if in.Metadata != nil {
 		flat := make(map[string]string, len(in.Metadata))
 		for k, val := range in.Metadata {
-			flat[k] = val.terraformValue()
+			flat[k] = alertMetadataTerraformValue(val)
 		}
 		if err := d.Set("metadata", flat); err != nil {
 			derr = append(derr, diag.FromErr(err)[0])
@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// apiErrorResponse is the body of a 422 response. errors is either a message, a list of messages or
+// an object mapping attribute names to (lists of) messages. Nested attributes are reported either as
+// nested objects or as keys like `custom_bucket.endpoint` or `queries[1].sql_query`.
+type apiErrorResponse struct {
+	Errors            json.RawMessage `json:"errors"`
+	InvalidAttributes []string        `json:"invalid_attributes"`
+}
+
+type apiFieldError struct {
+	key     string
+	message string
+}
+
+// apiErrorDiagnostics returns the diagnostics for a failed request. Validation errors (422) are
+// reported as one diagnostic per invalid attribute, with the attribute path as named by the API -
+// withAPIErrorPaths maps it to the resource schema. Anything else is reported with the raw body.
+func apiErrorDiagnostics(method, url string, statusCode int, body []byte) diag.Diagnostics {
+	detail := fmt.Sprintf("%s %s returned %d: %s", method, url, statusCode, string(body))
+	if statusCode != http.StatusUnprocessableEntity {
+		return diag.Errorf("%s", detail)
+	}
+	fieldErrors := parseAPIFieldErrors(body)
+	if len(fieldErrors) == 0 {
+		return diag.Errorf("%s", detail)
+	}
+
+	var diags diag.Diagnostics
+	for _, e := range fieldErrors {
+		summary := e.message
+		if e.key != "" {
+			summary = fmt.Sprintf("%s: %s", e.key, e.message)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid request: %s", summary),
+			Detail:        detail,
+			AttributePath: apiErrorAttributePath(e.key),
+		})
+	}
+	return diags
+}
+
+func parseAPIFieldErrors(body []byte) []apiFieldError {
+	var res apiErrorResponse
+	if err := json.Unmarshal(body, &res); err != nil || len(res.Errors) == 0 {
+		return nil
+	}
+
+	var message string
+	if err := json.Unmarshal(res.Errors, &message); err == nil {
+		// A single message, possibly naming the invalid attributes separately.
+		var out []apiFieldError
+		for _, attr := range res.InvalidAttributes {
+			out = append(out, apiFieldError{key: attr, message: message})
+		}
+		return out
+	}
+
+	var messages []string
+	if err := json.Unmarshal(res.Errors, &messages); err == nil {
+		var out []apiFieldError
+		for _, m := range messages {
+			out = append(out, apiFieldError{message: m})
+		}
+		return out
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(res.Errors, &fields); err != nil {
+		return nil
+	}
+	return flattenAPIFieldErrors("", fields)
+}
+
+// flattenAPIFieldErrors flattens nested error objects into dotted keys, sorted for stable output.
+func flattenAPIFieldErrors(prefix string, fields map[string]interface{}) []apiFieldError {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var out []apiFieldError
+	for _, k := range keys {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch v := fields[k].(type) {
+		case string:
+			out = append(out, apiFieldError{key: key, message: v})
+		case []interface{}:
+			for _, m := range v {
+				switch m := m.(type) {
+				case string:
+					out = append(out, apiFieldError{key: key, message: m})
+				case map[string]interface{}:
+					out = append(out, flattenAPIFieldErrors(key, m)...)
+				}
+			}
+		case map[string]interface{}:
+			out = append(out, flattenAPIFieldErrors(key, v)...)
+		}
+	}
+	return out
+}
+
+var apiErrorKeySeparator = regexp.MustCompile(`[.\[\]]+`)
+
+// apiErrorAttributePath converts an attribute key reported by the API, e.g. `queries[1].sql_query`,
+// to a path with an index step for each number.
+func apiErrorAttributePath(key string) cty.Path {
+	if key == "" {
+		return nil
+	}
+	var path cty.Path
+	for _, segment := range apiErrorKeySeparator.Split(key, -1) {
+		if segment == "" {
+			continue
+		}
+		if i, err := strconv.Atoi(segment); err == nil {
+			path = path.IndexInt(i)
+		} else {
+			path = path.GetAttr(segment)
+		}
+	}
+	return path
+}
+
+// withAPIErrorPaths maps the attribute paths of validation errors returned by the create and update
+// functions of r to its schema, so Terraform shows them next to the offending attribute. Attributes
+// are matched by name or by their plural (`queries` for a `query` block), blocks limited to a single
+// item get the missing `0` index (`custom_bucket.0.endpoint`). A path that doesn't match the schema
+// is cut at the last matching attribute.
+func withAPIErrorPaths(r *schema.Resource) *schema.Resource {
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			diags := f(ctx, d, meta)
+			for i := range diags {
+				if diags[i].AttributePath != nil {
+					diags[i].AttributePath = resolveAPIErrorPath(r.Schema, diags[i].AttributePath)
+				}
+			}
+			return diags
+		}
+	}
+	if r.CreateContext != nil {
+		r.CreateContext = wrap(r.CreateContext)
+	}
+	if r.UpdateContext != nil {
+		r.UpdateContext = wrap(r.UpdateContext)
+	}
+	return r
+}
+
+func resolveAPIErrorPath(s map[string]*schema.Schema, path cty.Path) cty.Path {
+	var out cty.Path
+	for len(path) > 0 {
+		step, ok := path[0].(cty.GetAttrStep)
+		if !ok {
+			break
+		}
+		name, attr := lookupAPIErrorAttribute(s, step.Name)
+		if attr == nil {
+			break
+		}
+		out = out.GetAttr(name)
+		path = path[1:]
+
+		elem, ok := attr.Elem.(*schema.Resource)
+		if !ok || attr.Type == schema.TypeSet {
+			// Primitive attributes and set elements can't be addressed any further.
+			break
+		}
+		if index, ok := firstIndexStep(path); ok {
+			out = append(out, index)
+			path = path[1:]
+		} else if attr.MaxItems == 1 && len(path) > 0 {
+			out = out.IndexInt(0)
+		} else {
+			break
+		}
+		s = elem.Schema
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func lookupAPIErrorAttribute(s map[string]*schema.Schema, name string) (string, *schema.Schema) {
+	candidates := []string{name, strings.TrimSuffix(name, "_attributes")}
+	if strings.HasSuffix(name, "ies") {
+		candidates = append(candidates, strings.TrimSuffix(name, "ies")+"y")
+	}
+	if strings.HasSuffix(name, "s") {
+		candidates = append(candidates, strings.TrimSuffix(name, "s"))
+	}
+	for _, c := range candidates {
+		if attr, ok := s[c]; ok {
+			return c, attr
+		}
+	}
+	return "", nil
+}
+
+func firstIndexStep(path cty.Path) (cty.IndexStep, bool) {
+	if len(path) == 0 {
+		return cty.IndexStep{}, false
+	}
+	index, ok := path[0].(cty.IndexStep)
+	return index, ok
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestAPIErrorDiagnostics(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		summaries []string
+		paths     []cty.Path
+	}{
+		{
+			name:      "field errors",
+			status:    http.StatusUnprocessableEntity,
+			body:      `{"errors":{"name":["can't be blank"],"custom_bucket.endpoint":["is not a valid URL","is too long"]}}`,
+			summaries: []string{"custom_bucket.endpoint: is not a valid URL", "custom_bucket.endpoint: is too long", "name: can't be blank"},
+			paths: []cty.Path{
+				cty.GetAttrPath("custom_bucket").GetAttr("endpoint"),
+				cty.GetAttrPath("custom_bucket").GetAttr("endpoint"),
+				cty.GetAttrPath("name"),
+			},
+		},
+		{
+			name:      "nested field errors",
+			status:    http.StatusUnprocessableEntity,
+			body:      `{"errors":{"queries":[{"1":{"sql_query":["is invalid"]}}]}}`,
+			summaries: []string{"queries.1.sql_query: is invalid"},
+			paths:     []cty.Path{cty.GetAttrPath("queries").IndexInt(1).GetAttr("sql_query")},
+		},
+		{
+			name:      "bracketed index",
+			status:    http.StatusUnprocessableEntity,
+			body:      `{"errors":{"queries[0].sql_query":"is invalid"}}`,
+			summaries: []string{"queries[0].sql_query: is invalid"},
+			paths:     []cty.Path{cty.GetAttrPath("queries").IndexInt(0).GetAttr("sql_query")},
+		},
+		{
+			name:      "message with invalid attributes",
+			status:    http.StatusUnprocessableEntity,
+			body:      `{"errors":"Custom S3 storage cannot be updated after creation","invalid_attributes":["custom_bucket"]}`,
+			summaries: []string{"custom_bucket: Custom S3 storage cannot be updated after creation"},
+			paths:     []cty.Path{cty.GetAttrPath("custom_bucket")},
+		},
+		{
+			name:      "list of messages",
+			status:    http.StatusUnprocessableEntity,
+			body:      `{"errors":["Plan limit reached"]}`,
+			summaries: []string{"Plan limit reached"},
+			paths:     []cty.Path{nil},
+		},
+		{
+			name:      "message only",
+			status:    http.StatusUnprocessableEntity,
+			body:      `{"errors":"boom"}`,
+			summaries: []string{"POST https://example.com/api returned 422: {\"errors\":\"boom\"}"},
+			paths:     []cty.Path{nil},
+		},
+		{
+			name:      "not a validation error",
+			status:    http.StatusInternalServerError,
+			body:      `{"errors":{"name":["can't be blank"]}}`,
+			summaries: []string{`POST https://example.com/api returned 500: {"errors":{"name":["can't be blank"]}}`},
+			paths:     []cty.Path{nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := apiErrorDiagnostics(http.MethodPost, "https://example.com/api", tt.status, []byte(tt.body))
+			if len(diags) != len(tt.summaries) {
+				t.Fatalf("got %d diagnostics, want %d: %v", len(diags), len(tt.summaries), diags)
+			}
+			for i, d := range diags {
+				if !strings.HasSuffix(d.Summary, tt.summaries[i]) {
+					t.Errorf("diagnostic %d: summary %q, want suffix %q", i, d.Summary, tt.summaries[i])
+				}
+				if !d.AttributePath.Equals(tt.paths[i]) {
+					t.Errorf("diagnostic %d: path %#v, want %#v", i, d.AttributePath, tt.paths[i])
+				}
+			}
+		})
+	}
+}
+
+func TestResolveAPIErrorPath(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want cty.Path
+	}{
+		{"single item block", "custom_bucket.endpoint", cty.GetAttrPath("custom_bucket").IndexInt(0).GetAttr("endpoint")},
+		{"attributes suffix", "custom_bucket_attributes.endpoint", cty.GetAttrPath("custom_bucket").IndexInt(0).GetAttr("endpoint")},
+		{"block", "custom_bucket", cty.GetAttrPath("custom_bucket")},
+		{"top-level attribute", "name", cty.GetAttrPath("name")},
+		{"unknown nested attribute", "custom_bucket.region", cty.GetAttrPath("custom_bucket").IndexInt(0)},
+		{"unknown attribute", "base", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveAPIErrorPath(sourceSchema, apiErrorAttributePath(tt.key))
+			if !got.Equals(tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	// The API names the list of chart queries `queries`, the schema has a `query` block.
+	got := resolveAPIErrorPath(dashboardChartSchema, apiErrorAttributePath("queries.1.sql_query"))
+	if want := cty.GetAttrPath("query").IndexInt(1).GetAttr("sql_query"); !got.Equals(want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
+	"github.com/betterstackhq/terraform-provider-logtail/internal/fakeapi"
+)
+
+func TestReadOnlyMode(t *testing.T) {
+	api := fakeapi.New(t)
+	id := api.Seed("/api/v1/source-groups", map[string]interface{}{"name": "Existing"})
+	p := configureTestProvider(t, api, map[string]interface{}{"read_only": true})
+	r := p.ResourcesMap["logtail_source_group"]
+	ctx := context.Background()
+
+	d := r.TestResourceData()
+	d.SetId(id)
+	if diags := r.ReadContext(ctx, d, p.Meta()); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got := d.Get("name"); got != "Existing" {
+		t.Errorf("got name %q", got)
+	}
+
+	// A refused create fails right away, without adopting the resource of the same name.
+	created := r.TestResourceData()
+	if err := created.Set("name", "Existing"); err != nil {
+		t.Fatal(err)
+	}
+	diags := r.CreateContext(ctx, created, p.Meta())
+	if !diags.HasError() || diags[0].Summary != "The provider is read-only" || !strings.Contains(diags[0].Detail, "POST "+api.URL+"/api/v1/source-groups wasn't sent") {
+		t.Errorf("got diagnostics %v, want the request refused", diags)
+	}
+	if created.Id() != "" {
+		t.Errorf("got ID %q, want the existing source group not adopted", created.Id())
+	}
+
+	diags = r.DeleteContext(ctx, d, p.Meta())
+	if !diags.HasError() || diags[0].Summary != "The provider is read-only" || !strings.Contains(diags[0].Detail, "DELETE "+api.URL+"/api/v1/source-groups/"+id+" wasn't sent") {
+		t.Errorf("got diagnostics %v, want the request refused", diags)
+	}
+	for _, req := range api.Requests() {
+		if !strings.HasPrefix(req, "GET ") {
+			t.Errorf("got request %s in read-only mode", req)
+		}
+	}
+}
+
+func TestAuditLogPath(t *testing.T) {
+	api := fakeapi.New(t)
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	p := configureTestProvider(t, api, map[string]interface{}{"audit_log_path": path})
+	r := p.ResourcesMap["logtail_source_group"]
+	ctx := context.Background()
+
+	d := r.TestResourceData()
+	if err := d.Set("name", "Backend"); err != nil {
+		t.Fatal(err)
+	}
+	if diags := r.CreateContext(ctx, d, p.Meta()); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if diags := r.DeleteContext(ctx, d, p.Meta()); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		var record betterstack.AuditRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid record %s: %v", line, err)
+		}
+		got = append(got, strings.Join([]string{record.Method, record.Path, record.Resource, record.ResourceID}, " "))
+	}
+	want := []string{
+		"POST /api/v1/source-groups logtail_source_group " + d.Id(),
+		"DELETE /api/v1/source-groups/" + d.Id() + " logtail_source_group " + d.Id(),
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got records\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	_, err = (&provider{url: api.URL}).buildClient(providerConfig{APIToken: "foo", AuditLogPath: filepath.Join(path, "missing", "audit.jsonl")})
+	if err == nil || !strings.Contains(err.Error(), "opening audit_log_path") {
+		t.Errorf("got error %v, want the unwritable audit log reported", err)
+	}
+}
@@ -1,42 +1,31 @@
 package provider
 
 import (
-	"context"
-	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"time"
 
-	"github.com/hashicorp/go-hclog"
-	"github.com/hashicorp/go-retryablehttp"
-	"golang.org/x/time/rate"
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 )
 
-func rateLimitRetryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
-	if err != nil {
-		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
-	}
-
-	if resp.StatusCode == 429 {
-		return true, nil
-	}
-
-	return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
-}
+const defaultErrorsBaseURL = betterstack.DefaultErrorsBaseURL
 
+// client is the API client the provider passes to resources as meta.
 type client struct {
-	baseURL       string
-	errorsBaseURL string
-	token         string
-	retryClient   *retryablehttp.Client
-	userAgent     string
-	rateLimiter   *rate.Limiter
+	*betterstack.Client
+	// defaultTeamName is used when creating team-scoped resources without team_name.
+	defaultTeamName string
+	catalog         *platformCatalog
 }
 
 type ClientConfig struct {
-	BaseURL      string
-	Token        string
+	BaseURL string
+	// ErrorsBaseURL is the base URL of the errors API. Defaults to https://errors.betterstack.com
+	// for the production BaseURL and to BaseURL otherwise, so a single test server receives both.
+	ErrorsBaseURL string
+	Token         string
+	// ErrorsToken authenticates requests to ErrorsBaseURL, defaults to Token.
+	ErrorsToken  string
 	UserAgent    string
 	HTTPClient   *http.Client
 	RetryMax     int
@@ -44,133 +33,37 @@ type ClientConfig struct {
 	RetryWaitMax time.Duration
 	RateLimit    int // requests per second, 0 = no limit
 	RateBurst    int // burst size for rate limiter, 0 = use default
+	// Cache memoizes GET responses until the next change, see betterstack.Config.Cache.
+	Cache bool
+	// ReadOnly refuses to send anything but GETs, see betterstack.Config.ReadOnly.
+	ReadOnly bool
+	// AuditLog receives a JSON line for every mutating request, see betterstack.AuditRecord.
+	AuditLog io.Writer
+	// DefaultTeamName is used when creating team-scoped resources without team_name.
+	DefaultTeamName string
 }
 
 func newClient(config ClientConfig) (*client, error) {
-	// Set reasonable bounds for max retries
-	if config.RetryMax < 0 || config.RetryMax > 10 {
-		config.RetryMax = 10
-	}
-	// Set default wait times
-	if config.RetryWaitMin == 0 {
-		config.RetryWaitMin = 1 * time.Second
-	}
-	if config.RetryWaitMax == 0 {
-		config.RetryWaitMax = 30 * time.Second
-	}
-
-	// Create retry client
-	retryClient := retryablehttp.NewClient()
-	retryClient.RetryMax = config.RetryMax
-	retryClient.RetryWaitMin = config.RetryWaitMin
-	retryClient.RetryWaitMax = config.RetryWaitMax
-	retryClient.CheckRetry = rateLimitRetryPolicy
-	retryClient.Backoff = retryablehttp.DefaultBackoff
-
-	// Use custom HTTP client if provided
-	if config.HTTPClient != nil {
-		retryClient.HTTPClient = config.HTTPClient
-	}
-
-	// Use hclog for Terraform-compatible logging (visible with TF_LOG=DEBUG)
-	logLevel := hclog.LevelFromString(os.Getenv("TF_LOG"))
-	if logLevel == hclog.NoLevel {
-		logLevel = hclog.Off
-	}
-	retryClient.Logger = hclog.New(&hclog.LoggerOptions{
-		Name:  "logtail-api",
-		Level: logLevel,
+	c, err := betterstack.New(betterstack.Config{
+		BaseURL:       config.BaseURL,
+		ErrorsBaseURL: config.ErrorsBaseURL,
+		Token:         config.Token,
+		ErrorsToken:   config.ErrorsToken,
+		UserAgent:     config.UserAgent,
+		HTTPClient:    config.HTTPClient,
+		RetryMax:      config.RetryMax,
+		RetryWaitMin:  config.RetryWaitMin,
+		RetryWaitMax:  config.RetryWaitMax,
+		RateLimit:     config.RateLimit,
+		RateBurst:     config.RateBurst,
+		Cache:         config.Cache,
+		ReadOnly:      config.ReadOnly,
+		AuditLog:      config.AuditLog,
+		Logger:        tflogLogger{},
+		LogSensitive:  logSensitive(),
 	})
-
-	// Create rate limiter if specified
-	var rateLimiter *rate.Limiter
-	if config.RateLimit > 0 {
-		burst := config.RateBurst
-		if burst <= 0 {
-			// Default burst: allow accumulating up to 2 seconds worth of requests
-			// This handles Terraform's pattern of idle-then-busy well
-			burst = config.RateLimit * 2
-			if burst < 10 {
-				burst = 10 // Minimum burst of 10 for reasonable performance
-			}
-		}
-		rateLimiter = rate.NewLimiter(rate.Limit(config.RateLimit), burst)
-	}
-
-	errorsBaseURL := "https://errors.betterstack.com"
-	// Override with test URL if baseURL is not the production URL
-	if config.BaseURL != "https://telemetry.betterstack.com" {
-		errorsBaseURL = config.BaseURL
-	}
-
-	return &client{
-		baseURL:       config.BaseURL,
-		errorsBaseURL: errorsBaseURL,
-		token:         config.Token,
-		retryClient:   retryClient,
-		userAgent:     config.UserAgent,
-		rateLimiter:   rateLimiter,
-	}, nil
-}
-
-func (c *client) Get(ctx context.Context, path string) (*http.Response, error) {
-	return c.do(ctx, http.MethodGet, c.baseURL, path, nil)
-}
-
-func (c *client) Post(ctx context.Context, path string, body io.Reader) (*http.Response, error) {
-	return c.do(ctx, http.MethodPost, c.baseURL, path, body)
-}
-
-func (c *client) Patch(ctx context.Context, path string, body io.Reader) (*http.Response, error) {
-	return c.do(ctx, http.MethodPatch, c.baseURL, path, body)
-}
-
-func (c *client) Delete(ctx context.Context, path string) (*http.Response, error) {
-	return c.do(ctx, http.MethodDelete, c.baseURL, path, nil)
-}
-
-func (c *client) GetWithBaseURL(ctx context.Context, baseURL, path string) (*http.Response, error) {
-	return c.do(ctx, http.MethodGet, baseURL, path, nil)
-}
-
-func (c *client) PostWithBaseURL(ctx context.Context, baseURL, path string, body io.Reader) (*http.Response, error) {
-	return c.do(ctx, http.MethodPost, baseURL, path, body)
-}
-
-func (c *client) PatchWithBaseURL(ctx context.Context, baseURL, path string, body io.Reader) (*http.Response, error) {
-	return c.do(ctx, http.MethodPatch, baseURL, path, body)
-}
-
-func (c *client) DeleteWithBaseURL(ctx context.Context, baseURL, path string) (*http.Response, error) {
-	return c.do(ctx, http.MethodDelete, baseURL, path, nil)
-}
-
-func (c *client) TelemetryBaseURL() string {
-	return c.baseURL
-}
-
-func (c *client) ErrorsBaseURL() string {
-	return c.errorsBaseURL
-}
-
-func (c *client) do(ctx context.Context, method, baseURL, path string, body io.Reader) (*http.Response, error) {
-	// Apply rate limiting if configured
-	if c.rateLimiter != nil {
-		if err := c.rateLimiter.Wait(ctx); err != nil {
-			return nil, fmt.Errorf("rate limiter: %w", err)
-		}
-	}
-
-	req, err := retryablehttp.NewRequest(method, fmt.Sprintf("%s%s", baseURL, path), body)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
-	if c.userAgent != "" {
-		req.Header.Set("User-Agent", c.userAgent)
-	}
-	if method == http.MethodPost || method == http.MethodPatch {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	return c.retryClient.Do(req.WithContext(ctx))
+	return &client{Client: c, defaultTeamName: config.DefaultTeamName, catalog: &platformCatalog{}}, nil
 }
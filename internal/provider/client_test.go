@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -292,3 +293,87 @@ func TestClientBurstBehavior(t *testing.T) {
 		t.Errorf("Rate limited request completed too quickly: %v (expected >= 400ms)", limitedDuration)
 	}
 }
+
+func TestClientBaseURLs(t *testing.T) {
+	telemetryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("telemetry"))
+	}))
+	defer telemetryServer.Close()
+	errorsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("errors"))
+	}))
+	defer errorsServer.Close()
+
+	tests := []struct {
+		name       string
+		config     ClientConfig
+		wantErrors string
+	}{
+		{"production", ClientConfig{BaseURL: "https://telemetry.betterstack.com"}, "https://errors.betterstack.com"},
+		{"test server", ClientConfig{BaseURL: telemetryServer.URL}, telemetryServer.URL},
+		{"explicit errors URL", ClientConfig{BaseURL: telemetryServer.URL, ErrorsBaseURL: errorsServer.URL}, errorsServer.URL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newClient(tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.TelemetryBaseURL() != tt.config.BaseURL {
+				t.Errorf("TelemetryBaseURL() = %q, want %q", c.TelemetryBaseURL(), tt.config.BaseURL)
+			}
+			if c.ErrorsBaseURL() != tt.wantErrors {
+				t.Errorf("ErrorsBaseURL() = %q, want %q", c.ErrorsBaseURL(), tt.wantErrors)
+			}
+		})
+	}
+
+	c, err := newClient(ClientConfig{BaseURL: telemetryServer.URL, ErrorsBaseURL: errorsServer.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for baseURL, want := range map[string]string{c.TelemetryBaseURL(): "telemetry", c.ErrorsBaseURL(): "errors"} {
+		resp, err := c.GetWithBaseURL(context.Background(), baseURL, "/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if string(body) != want {
+			t.Errorf("GET %s reached the %q server, want %q", baseURL, body, want)
+		}
+	}
+}
+
+func TestClientAPICache(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	for _, tt := range []struct {
+		apiCache     bool
+		wantRequests int32
+	}{
+		{true, 1},
+		{false, 3},
+	} {
+		atomic.StoreInt32(&requestCount, 0)
+		c, err := (&provider{url: server.URL}).buildClient(providerConfig{APIToken: "foo", APITimeout: 5, APICache: tt.apiCache})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 3; i++ {
+			resp, err := c.Get(context.Background(), "/api/v2/dashboards/templates")
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = resp.Body.Close()
+		}
+		if got := atomic.LoadInt32(&requestCount); got != tt.wantRequests {
+			t.Errorf("api_cache = %v: got %d requests, want %d", tt.apiCache, got, tt.wantRequests)
+		}
+	}
+}
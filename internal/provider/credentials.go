@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// apiTokenCommandTimeout bounds how long api_token_command may take, e.g. waiting for a login.
+const apiTokenCommandTimeout = 2 * time.Minute
+
+// apiTokenFile returns the configured api_token_file, or else the LOGTAIL_API_TOKEN_FILE environment
+// variable unless api_token or api_token_command is configured - the configuration takes precedence
+// over the environment.
+func apiTokenFile(configured string, tokenConfigured bool) string {
+	if configured != "" || tokenConfigured {
+		return configured
+	}
+	return os.Getenv("LOGTAIL_API_TOKEN_FILE")
+}
+
+// resolveAPIToken returns the Telemetry API token from api_token_file, api_token_command or
+// api_token, in this order - the explicitly configured file or command take precedence over the
+// LOGTAIL_API_TOKEN environment variable.
+func resolveAPIToken(cfg providerConfig) (string, error) {
+	switch {
+	case cfg.APITokenFile != "":
+		b, err := os.ReadFile(cfg.APITokenFile)
+		if err != nil {
+			return "", fmt.Errorf("reading api_token_file: %w", err)
+		}
+		token := strings.TrimSpace(string(b))
+		if token == "" {
+			return "", fmt.Errorf("api_token_file %s is empty", cfg.APITokenFile)
+		}
+		return token, nil
+	case len(cfg.APITokenCommand) > 0:
+		return runAPITokenCommand(cfg.APITokenCommand)
+	case cfg.APIToken != "":
+		return cfg.APIToken, nil
+	default:
+		return "", errors.New("one of api_token, api_token_file or api_token_command must be set, or the LOGTAIL_API_TOKEN environment variable")
+	}
+}
+
+// runAPITokenCommand runs a credential helper printing the token to stdout. It is run once per
+// configured client, the SDKv2 and framework providers of a mux server share its output.
+func runAPITokenCommand(command []string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), apiTokenCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("api_token_command %s failed: %w: %s", command[0], err, msg)
+		}
+		return "", fmt.Errorf("api_token_command %s failed: %w", command[0], err)
+	}
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("api_token_command %s didn't print a token", command[0])
+	}
+	return token, nil
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/betterstackhq/terraform-provider-logtail/internal/fakeapi"
+)
+
+func TestResolveAPIToken(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		cfg     providerConfig
+		want    string
+		wantErr string
+	}{
+		{"api_token", providerConfig{APIToken: "foo"}, "foo", ""},
+		{"api_token_file", providerConfig{APIToken: "from-env", APITokenFile: tokenFile}, "from-file", ""},
+		{"api_token_command", providerConfig{APIToken: "from-env", APITokenCommand: []string{"sh", "-c", "echo from-command"}}, "from-command", ""},
+		{"empty api_token_file", providerConfig{APITokenFile: emptyFile}, "", "is empty"},
+		{"missing api_token_file", providerConfig{APITokenFile: filepath.Join(dir, "missing")}, "", "reading api_token_file"},
+		{"failing api_token_command", providerConfig{APITokenCommand: []string{"sh", "-c", "echo not logged in >&2; exit 1"}}, "", "api_token_command sh failed: exit status 1: not logged in"},
+		{"silent api_token_command", providerConfig{APITokenCommand: []string{"true"}}, "", "didn't print a token"},
+		{"none", providerConfig{}, "", "one of api_token, api_token_file or api_token_command must be set"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveAPIToken(tt.cfg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got token %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAPITokenFileEnvironment verifies LOGTAIL_API_TOKEN_FILE doesn't override a configured token.
+func TestAPITokenFileEnvironment(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("from-env-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("LOGTAIL_API_TOKEN_FILE", tokenFile)
+
+	if got := apiTokenFile("", false); got != tokenFile {
+		t.Errorf("got api_token_file %q without a configured token, want the environment variable", got)
+	}
+	if got := apiTokenFile("", true); got != "" {
+		t.Errorf("got api_token_file %q with a configured token, want none", got)
+	}
+	if got := apiTokenFile("/configured", true); got != "/configured" {
+		t.Errorf("got api_token_file %q, want the configured one", got)
+	}
+
+	// The fake API only accepts its own token, the api_token of the provider block.
+	api := fakeapi.New(t)
+	id := api.Seed("/api/v1/source-groups", map[string]interface{}{"name": "Existing"})
+	p := configureTestProvider(t, api, map[string]interface{}{})
+	r := p.ResourcesMap["logtail_source_group"]
+	d := r.TestResourceData()
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, p.Meta()); diags.HasError() {
+		t.Fatal(diags)
+	}
+}
+
+// TestAPITokenCommandRunsOnce verifies the SDKv2 and framework providers of a mux server, which
+// configure the same client, run api_token_command only once.
+func TestAPITokenCommandRunsOnce(t *testing.T) {
+	runs := filepath.Join(t.TempDir(), "runs")
+	command := []string{"sh", "-c", `echo run >> "$0"; echo token`, runs}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("got Authorization %q, want the token printed by the command", got)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	p := &provider{url: server.URL, shared: &sharedClient{}}
+	for i := 0; i < 2; i++ {
+		c, err := p.newClient(providerConfig{APITokenCommand: command})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := c.Get(context.Background(), "/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+	b, err := os.ReadFile(runs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(b), "run"); n != 1 {
+		t.Errorf("api_token_command ran %d times, want once", n)
+	}
+}
+
+func TestErrorsAPIToken(t *testing.T) {
+	newServer := func(wantToken string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got := r.Header.Get("Authorization"); got != "Bearer "+wantToken {
+				t.Errorf("%s: got Authorization %q, want token %q", r.RequestURI, got, wantToken)
+			}
+			_, _ = w.Write([]byte(`{}`))
+		}))
+	}
+	telemetryServer := newServer("telemetry")
+	defer telemetryServer.Close()
+	errorsServer := newServer("errors")
+	defer errorsServer.Close()
+
+	c, err := (&provider{}).buildClient(providerConfig{
+		APIToken:       "telemetry",
+		ErrorsAPIToken: "errors",
+		TelemetryURL:   telemetryServer.URL,
+		ErrorsURL:      errorsServer.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for _, baseURL := range []string{c.TelemetryBaseURL(), c.ErrorsBaseURL()} {
+		resp, err := c.GetWithBaseURL(ctx, baseURL, "/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+}
@@ -2,19 +2,16 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func newCollectorDataSource() *schema.Resource {
 	s := make(map[string]*schema.Schema)
-	for k, v := range collectorSchema {
+	for k, v := range withoutWriteOnly(collectorSchema) {
 		cp := *v
 		switch k {
 		case "name":
@@ -33,6 +30,9 @@ func newCollectorDataSource() *schema.Resource {
 			cp.DiffSuppressFunc = nil
 			cp.MaxItems = 0
 		}
+		if k == "team_name" {
+			cp.Description = dataSourceTeamNameDescription
+		}
 		s[k] = &cp
 	}
 	return &schema.Resource{
@@ -43,51 +43,24 @@ func newCollectorDataSource() *schema.Resource {
 }
 
 func collectorLookup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fetch := func(page int) (*collectorPageHTTPResponse, error) {
-		name := d.Get("name").(string)
-		res, err := meta.(*client).Get(ctx, fmt.Sprintf("/api/v1/collectors?name=%s&page=%d", url.QueryEscape(name), page))
-		if err != nil {
-			return nil, err
-		}
-		defer func() {
-			_, _ = io.Copy(io.Discard, res.Body)
-			_ = res.Body.Close()
-		}()
-		body, err := io.ReadAll(res.Body)
-		if res.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-		}
-		if err != nil {
-			return nil, err
-		}
-		var tr collectorPageHTTPResponse
-		return &tr, json.Unmarshal(body, &tr)
-	}
-
 	name := d.Get("name").(string)
-	page := 1
-	for {
-		res, err := fetch(page)
+	c := meta.(*client)
+	for e, err := range betterstack.Paginate[collector](ctx, c.Client, c.TelemetryBaseURL(), "/api/v1/collectors?name="+url.QueryEscape(name)) {
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		for _, e := range res.Data {
-			if e.Attributes.Name != nil && *e.Attributes.Name == name {
-				if d.Id() != "" {
-					return diag.Errorf("duplicate collector found with name %q", name)
-				}
-				d.SetId(e.ID)
-				if derr := collectorCopyAttrs(d, &e.Attributes); derr != nil {
-					return derr
-				}
+		if e.Attributes.Name != nil && *e.Attributes.Name == name {
+			if d.Id() != "" {
+				return diag.Errorf("duplicate collector found with name %q", name)
 			}
-		}
-		page++
-		if res.Pagination.Next == "" {
-			if d.Id() == "" {
-				return diag.Errorf("collector with name %q not found", name)
+			d.SetId(e.ID)
+			if derr := collectorCopyAttrs(d, &e.Attributes); derr != nil {
+				return derr
 			}
-			return nil
 		}
 	}
+	if d.Id() == "" {
+		return diag.Errorf("collector with name %q not found", name)
+	}
+	return nil
 }
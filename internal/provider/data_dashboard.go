@@ -10,15 +10,13 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-type dashboardListItem struct {
-	ID         string             `json:"id"`
-	Type       string             `json:"type"`
-	Attributes dashboardListAttrs `json:"attributes"`
-}
+// dashboardListItem is a dashboard summary returned by the list endpoint.
+type dashboardListItem = betterstack.Item[dashboardListAttrs]
 
 type dashboardListAttrs struct {
 	TeamID    *int    `json:"team_id,omitempty"`
@@ -40,16 +38,6 @@ type templateListAttrs struct {
 	Categories  []string `json:"categories,omitempty"`
 }
 
-type dashboardPageHTTPResponse struct {
-	Data       []dashboardListItem `json:"data"`
-	Pagination struct {
-		First string `json:"first"`
-		Last  string `json:"last"`
-		Prev  string `json:"prev"`
-		Next  string `json:"next"`
-	} `json:"pagination"`
-}
-
 type templatePageHTTPResponse struct {
 	Data []templateListItem `json:"data"`
 }
@@ -156,29 +144,6 @@ func dataSourceDashboardRead(ctx context.Context, d *schema.ResourceData, meta i
 		return diag.Errorf("either id or name must be specified")
 	}
 
-	// Fetch function for getting dashboards list
-	fetch := func(page int) (*dashboardPageHTTPResponse, error) {
-		path := fmt.Sprintf("/api/v2/dashboards?page=%d", page)
-		res, err := meta.(*client).Get(ctx, path)
-		if err != nil {
-			return nil, err
-		}
-		defer func() {
-			// Keep-Alive.
-			_, _ = io.Copy(io.Discard, res.Body)
-			_ = res.Body.Close()
-		}()
-		body, err := io.ReadAll(res.Body)
-		if res.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-		}
-		if err != nil {
-			return nil, err
-		}
-		var tr dashboardPageHTTPResponse
-		return &tr, json.Unmarshal(body, &tr)
-	}
-
 	// If ID is provided, look up directly by ID. Never search the paginated
 	// list for an ID: concurrent dashboard deletions shift items between
 	// pages mid-iteration, so an existing dashboard can be skipped.
@@ -257,25 +222,18 @@ func dataSourceDashboardRead(ctx context.Context, d *schema.ResourceData, meta i
 
 	// Collect all matching dashboards across all pages
 	var matchingDashboards []dashboardListItem
-	page := 1
-	for {
-		res, err := fetch(page)
+	c := meta.(*client)
+	for e, err := range betterstack.Paginate[dashboardListAttrs](ctx, c.Client, c.TelemetryBaseURL(), "/api/v2/dashboards") {
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		for _, e := range res.Data {
-			// Check if name matches
-			if e.Attributes.Name != nil && *e.Attributes.Name == name {
-				// Check if team_name matches (if specified)
-				if teamName != "" && (e.Attributes.TeamName == nil || *e.Attributes.TeamName != teamName) {
-					continue
-				}
-				matchingDashboards = append(matchingDashboards, e)
+		// Check if name matches
+		if e.Attributes.Name != nil && *e.Attributes.Name == name {
+			// Check if team_name matches (if specified)
+			if teamName != "" && (e.Attributes.TeamName == nil || *e.Attributes.TeamName != teamName) {
+				continue
 			}
-		}
-		page++
-		if res.Pagination.Next == "" {
-			break
+			matchingDashboards = append(matchingDashboards, e)
 		}
 	}
 
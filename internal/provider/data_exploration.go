@@ -2,10 +2,7 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -36,6 +33,9 @@ func newExplorationDataSource() *schema.Resource {
 			cp.MinItems = 0
 			cp.MaxItems = 0
 		}
+		if k == "team_name" {
+			cp.Description = dataSourceTeamNameDescription
+		}
 		s[k] = &cp
 	}
 
@@ -46,78 +46,20 @@ func newExplorationDataSource() *schema.Resource {
 	}
 }
 
-// Summary response for list endpoint (no chart/queries/variables)
-type explorationsHTTPResponse struct {
-	Data []struct {
-		ID         string `json:"id"`
-		Attributes struct {
-			Name               *string `json:"name,omitempty"`
-			DateRangeFrom      *string `json:"date_range_from,omitempty"`
-			DateRangeTo        *string `json:"date_range_to,omitempty"`
-			ExplorationGroupID *int    `json:"exploration_group_id,omitempty"`
-			CreatedAt          *string `json:"created_at,omitempty"`
-			UpdatedAt          *string `json:"updated_at,omitempty"`
-		} `json:"attributes"`
-	} `json:"data"`
-	Pagination struct {
-		Next *string `json:"next"`
-	} `json:"pagination"`
-}
-
 func explorationLookup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	name := d.Get("name").(string)
 	c := meta.(*client)
 
-	// Fetch function for listing explorations
-	fetchList := func(u string) (*explorationsHTTPResponse, error) {
-		res, err := c.Get(ctx, u)
-		if err != nil {
-			return nil, err
-		}
-		defer func() {
-			_, _ = io.Copy(io.Discard, res.Body)
-			_ = res.Body.Close()
-		}()
-		body, err := io.ReadAll(res.Body)
-		if res.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-		}
-		if err != nil {
-			return nil, err
-		}
-		var out explorationsHTTPResponse
-		return &out, json.Unmarshal(body, &out)
-	}
-
-	// First, find the exploration by name using the list endpoint
+	// First, find the exploration by name using the list endpoint, which only returns a summary
 	var foundID string
-	page := "/api/v2/explorations?page=1"
-	for {
-		out, err := fetchList(page)
+	for item, err := range c.Explorations().List(ctx) {
 		if err != nil {
 			return diag.FromErr(err)
 		}
-
-		for _, item := range out.Data {
-			if item.Attributes.Name != nil && *item.Attributes.Name == name {
-				foundID = item.ID
-				break
-			}
-		}
-
-		if foundID != "" {
+		if item.Attributes.Name != nil && *item.Attributes.Name == name {
+			foundID = item.ID
 			break
 		}
-
-		if out.Pagination.Next == nil {
-			break
-		}
-
-		if u, err := url.Parse(*out.Pagination.Next); err != nil {
-			return diag.FromErr(err)
-		} else {
-			page = u.RequestURI()
-		}
 	}
 
 	if foundID == "" {
@@ -2,10 +2,6 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -13,7 +9,7 @@ import (
 
 func newSourceDataSource() *schema.Resource {
 	s := make(map[string]*schema.Schema)
-	for k, v := range sourceSchema {
+	for k, v := range withoutWriteOnly(sourceSchema) {
 		cp := *v
 		switch k {
 		case "table_name":
@@ -42,6 +38,9 @@ func newSourceDataSource() *schema.Resource {
 			cp.DiffSuppressFunc = nil
 			cp.ConflictsWith = nil
 		}
+		if k == "team_name" {
+			cp.Description = dataSourceTeamNameDescription
+		}
 		s[k] = &cp
 	}
 	return &schema.Resource{
@@ -51,61 +50,21 @@ func newSourceDataSource() *schema.Resource {
 	}
 }
 
-type sourcePageHTTPResponse struct {
-	Data []struct {
-		ID         string `json:"id"`
-		Attributes source `json:"attributes"`
-	} `json:"data"`
-	Pagination struct {
-		First string `json:"first"`
-		Last  string `json:"last"`
-		Prev  string `json:"prev"`
-		Next  string `json:"next"`
-	} `json:"pagination"`
-}
-
 func sourceLookup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fetch := func(page int) (*sourcePageHTTPResponse, error) {
-		res, err := meta.(*client).Get(ctx, fmt.Sprintf("/api/v2/sources?page=%d", page))
-		if err != nil {
-			return nil, err
-		}
-		defer func() {
-			// Keep-Alive.
-			_, _ = io.Copy(io.Discard, res.Body)
-			_ = res.Body.Close()
-		}()
-		body, err := io.ReadAll(res.Body)
-		if res.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-		}
-		if err != nil {
-			return nil, err
-		}
-		var tr sourcePageHTTPResponse
-		return &tr, json.Unmarshal(body, &tr)
-	}
 	table_name := d.Get("table_name").(string)
-	page := 1
-	for {
-		res, err := fetch(page)
+	for e, err := range meta.(*client).Sources().List(ctx) {
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		for _, e := range res.Data {
-			if *e.Attributes.TableName == table_name {
-				if d.Id() != "" {
-					return diag.Errorf("duplicate")
-				}
-				d.SetId(e.ID)
-				if derr := sourceCopyAttrs(d, &e.Attributes); derr != nil {
-					return derr
-				}
+		if *e.Attributes.TableName == table_name {
+			if d.Id() != "" {
+				return diag.Errorf("duplicate")
+			}
+			d.SetId(e.ID)
+			if derr := sourceCopyAttrs(d, &e.Attributes); derr != nil {
+				return derr
 			}
-		}
-		page++
-		if res.Pagination.Next == "" {
-			return nil
 		}
 	}
+	return nil
 }
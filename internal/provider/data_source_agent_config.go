@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// agentConfig is the configuration file of a log shipping agent sending data to a source.
+type agentConfig struct {
+	fileName string
+	template *template.Template
+}
+
+// agentConfigData is passed to the agent config templates.
+type agentConfigData struct {
+	Token         string
+	IngestingHost string
+	Paths         []string
+}
+
+var agentConfigFuncs = template.FuncMap{
+	// quote returns a double-quoted string, valid in TOML, YAML and the agents' own formats.
+	"quote": strconv.Quote,
+	"quoteList": func(values []string) string {
+		quoted := make([]string, len(values))
+		for i, v := range values {
+			quoted[i] = strconv.Quote(v)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	},
+	"join": strings.Join,
+	"dir":  path.Dir,
+	"base": path.Base,
+}
+
+func newAgentConfig(fileName, text string) agentConfig {
+	return agentConfig{fileName: fileName, template: template.Must(template.New(fileName).Funcs(agentConfigFuncs).Parse(text))}
+}
+
+// agentConfigs maps the platforms of logtail_source that are log shipping agents to their
+// configuration. Each one tails the files in Paths and sends them to the ingesting host of the
+// source, over HTTPS or, for the syslog daemons, syslog over TLS.
+var agentConfigs = map[string]agentConfig{
+	"vector": newAgentConfig("vector.toml", `[sources.better_stack_files]
+type = "file"
+include = {{quoteList .Paths}}
+
+[sinks.better_stack]
+type = "http"
+method = "post"
+inputs = ["better_stack_files"]
+uri = {{quote (printf "https://%s/" .IngestingHost)}}
+encoding.codec = "json"
+compression = "gzip"
+auth.strategy = "bearer"
+auth.token = {{quote .Token}}
+`),
+	"fluentbit": newAgentConfig("fluent-bit.conf", `[INPUT]
+    Name  tail
+    Path  {{join .Paths ","}}
+
+[OUTPUT]
+    Name              http
+    Match             *
+    Host              {{.IngestingHost}}
+    Port              443
+    URI               /
+    Header            Authorization Bearer {{.Token}}
+    Format            json
+    Json_date_key     dt
+    Json_date_format  iso8601
+    tls               On
+`),
+	"fluentd": newAgentConfig("fluent.conf", `<source>
+  @type tail
+  path {{join .Paths ","}}
+  pos_file /var/log/fluentd/better-stack.pos
+  tag better_stack
+  <parse>
+    @type none
+  </parse>
+</source>
+
+<match better_stack>
+  @type http
+  endpoint https://{{.IngestingHost}}/
+  headers {"Authorization": "Bearer {{.Token}}"}
+  json_array true
+  <format>
+    @type json
+  </format>
+  <buffer>
+    flush_interval 2s
+  </buffer>
+</match>
+`),
+	"open_telemetry": newAgentConfig("otel-collector.yaml", `receivers:
+  filelog:
+    include: {{quoteList .Paths}}
+
+exporters:
+  otlphttp/better_stack:
+    endpoint: {{quote (printf "https://%s" .IngestingHost)}}
+    headers:
+      Authorization: {{quote (printf "Bearer %s" .Token)}}
+
+service:
+  pipelines:
+    logs:
+      receivers: [filelog]
+      exporters: [otlphttp/better_stack]
+`),
+	"rsyslog": newAgentConfig("better-stack.conf", `module(load="imfile")
+{{range .Paths}}input(type="imfile" File={{quote .}} Tag="better-stack")
+{{end}}
+global(DefaultNetstreamDriverCAFile="/etc/ssl/certs/ca-certificates.crt")
+
+template(name="BetterStackFormat" type="string"
+         string="<%pri%>1 %timestamp:::date-rfc3339% %HOSTNAME% %app-name% %procid% %msgid% [logtail@11993 source_token=\"{{.Token}}\"] %msg:::sp-if-no-1st-sp%%msg%")
+
+action(type="omfwd" protocol="tcp" target={{quote .IngestingHost}} port="6514" template="BetterStackFormat"
+       StreamDriver="gtls" StreamDriverMode="1" StreamDriverAuthMode="x509/name" StreamDriverPermittedPeers={{quote .IngestingHost}}
+       queue.type="LinkedList" queue.spoolDirectory="/var/spool/rsyslog" queue.filename="better_stack"
+       queue.maxDiskSpace="75m" queue.saveOnShutdown="on" action.resumeRetryCount="-1")
+`),
+	"syslog-ng": newAgentConfig("better-stack.conf", `{{range $i, $p := .Paths}}source s_better_stack_{{$i}} {
+  wildcard-file(base-dir({{quote (dir $p)}}) filename-pattern({{quote (base $p)}}) flags(no-parse));
+};
+{{end}}
+template t_better_stack {
+  template("<${PRI}>1 ${ISODATE} ${HOST} ${PROGRAM} ${PID} ${MSGID} [logtail@11993 source_token=\"{{.Token}}\"] $MSG\n");
+};
+
+destination d_better_stack {
+  network({{quote .IngestingHost}} port(6514) transport("tls") template(t_better_stack) tls(peer-verify(required-trusted)));
+};
+
+log {
+{{range $i, $p := .Paths}}  source(s_better_stack_{{$i}});
+{{end}}  destination(d_better_stack);
+};
+`),
+	"logstash": newAgentConfig("logstash.conf", `input {
+  file {
+    path => {{quoteList .Paths}}
+  }
+}
+
+output {
+  http {
+    url => {{quote (printf "https://%s/" .IngestingHost)}}
+    http_method => "post"
+    headers => { "Authorization" => {{quote (printf "Bearer %s" .Token)}} }
+    format => "json_batch"
+  }
+}
+`),
+	"filebeat": newAgentConfig("filebeat.yml", `filebeat.inputs:
+  - type: filestream
+    id: better-stack
+    paths: {{quoteList .Paths}}
+
+output.elasticsearch:
+  hosts: [{{quote (printf "https://%s:443" .IngestingHost)}}]
+  path: "/es/"
+  headers:
+    Authorization: {{quote (printf "Bearer %s" .Token)}}
+  allow_older_versions: true
+
+setup.ilm.enabled: false
+setup.template.enabled: false
+`),
+}
+
+// agentConfigAgents returns the agents agentConfigs renders a configuration for, in the order of
+// platformTypes.
+func agentConfigAgents() []string {
+	var agents []string
+	for _, platform := range platformTypes {
+		if _, ok := agentConfigs[platform]; ok {
+			agents = append(agents, platform)
+		}
+	}
+	return agents
+}
+
+func newSourceAgentConfigDataSource() *schema.Resource {
+	agents := agentConfigAgents()
+	return &schema.Resource{
+		ReadContext: sourceAgentConfigRead,
+		Description: "This Data Source renders the configuration file of a log shipping agent sending the logs of local files to a source, with its `token` and `ingesting_host` filled in.",
+		Schema: map[string]*schema.Schema{
+			"source_id": {
+				Description: "The ID of the source the agent sends data to.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"agent": {
+				Description:  "The agent to configure, defaults to the `platform` of the source. Valid values are:\n    - `" + strings.Join(agents, "`\n    - `") + "`",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(agents, false),
+			},
+			"paths": {
+				Description: "The log files the agent reads, glob patterns are allowed. Defaults to `[\"/var/log/*.log\"]`.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+			"file_name": {
+				Description: "The conventional name of the configuration file, e.g. `vector.toml`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"config": {
+				Description: "The configuration file. It contains the token of the source.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func sourceAgentConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sourceID := d.Get("source_id").(string)
+	var out betterstack.Response[source]
+	if derr, ok := resourceReadWithBaseURL(ctx, meta, meta.(*client).TelemetryBaseURL(), meta.(*client).Sources().ItemPath(sourceID), &out); derr != nil {
+		return derr
+	} else if !ok {
+		return diag.Errorf("source with ID %s not found", sourceID)
+	}
+	in := out.Data.Attributes
+
+	agent := d.Get("agent").(string)
+	if agent == "" && in.Platform != nil {
+		agent = *in.Platform
+	}
+	config, ok := agentConfigs[agent]
+	if !ok {
+		return diag.Errorf("no agent configuration for platform %q of source %s, set agent to one of %v", agent, sourceID, agentConfigAgents())
+	}
+	if in.Token == nil || in.IngestingHost == nil || *in.IngestingHost == "" {
+		return diag.Errorf("source %s has no token or ingesting_host yet", sourceID)
+	}
+
+	data := agentConfigData{Token: *in.Token, IngestingHost: *in.IngestingHost, Paths: []string{"/var/log/*.log"}}
+	if v, ok := d.GetOk("paths"); ok {
+		data.Paths = nil
+		for _, p := range v.([]interface{}) {
+			data.Paths = append(data.Paths, p.(string))
+		}
+	}
+	var b bytes.Buffer
+	if err := config.template.Execute(&b, data); err != nil {
+		return diag.FromErr(fmt.Errorf("rendering the %s configuration: %w", agent, err))
+	}
+
+	d.SetId(sourceID + "/" + agent)
+	if err := d.Set("agent", agent); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("file_name", config.fileName); err != nil {
+		return diag.FromErr(err)
+	}
+	return diag.FromErr(d.Set("config", b.String()))
+}
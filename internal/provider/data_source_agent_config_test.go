@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/betterstackhq/terraform-provider-logtail/internal/fakeapi"
+)
+
+func TestDataSourceAgentConfig(t *testing.T) {
+	api := fakeapi.New(t)
+	vectorID := api.Seed("/api/v2/sources", map[string]interface{}{"name": "Vector", "platform": "vector", "token": "t0ken", "ingesting_host": "s1.eu-nbg-2.betterstackdata.com"})
+	ubuntuID := api.Seed("/api/v2/sources", map[string]interface{}{"name": "Ubuntu", "platform": "ubuntu", "token": "t0ken", "ingesting_host": "s2.eu-nbg-2.betterstackdata.com"})
+	p := configureTestProvider(t, api, map[string]interface{}{})
+	r := p.DataSourcesMap["logtail_source_agent_config"]
+	ctx := context.Background()
+
+	read := func(t *testing.T, args map[string]interface{}) (string, string, string, string) {
+		t.Helper()
+		d := r.TestResourceData()
+		for k, v := range args {
+			if err := d.Set(k, v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if diags := r.ReadContext(ctx, d, p.Meta()); diags.HasError() {
+			return "", "", "", diags[0].Summary
+		}
+		return d.Get("agent").(string), d.Get("file_name").(string), d.Get("config").(string), ""
+	}
+
+	t.Run("platform of the source", func(t *testing.T) {
+		agent, fileName, config, err := read(t, map[string]interface{}{"source_id": vectorID, "paths": []interface{}{"/var/log/app/*.log", "/var/log/syslog"}})
+		if err != "" {
+			t.Fatal(err)
+		}
+		want := `[sources.better_stack_files]
+type = "file"
+include = ["/var/log/app/*.log", "/var/log/syslog"]
+
+[sinks.better_stack]
+type = "http"
+method = "post"
+inputs = ["better_stack_files"]
+uri = "https://s1.eu-nbg-2.betterstackdata.com/"
+encoding.codec = "json"
+compression = "gzip"
+auth.strategy = "bearer"
+auth.token = "t0ken"
+`
+		if agent != "vector" || fileName != "vector.toml" || config != want {
+			t.Errorf("got agent %q, file_name %q and config\n%s\nwant\n%s", agent, fileName, config, want)
+		}
+	})
+
+	for _, agent := range agentConfigAgents() {
+		t.Run(agent, func(t *testing.T) {
+			gotAgent, fileName, config, err := read(t, map[string]interface{}{"source_id": ubuntuID, "agent": agent})
+			if err != "" {
+				t.Fatal(err)
+			}
+			if gotAgent != agent || fileName == "" || !strings.Contains(config, "t0ken") || !strings.Contains(config, "s2.eu-nbg-2.betterstackdata.com") || !strings.Contains(config, "/var/log") {
+				t.Errorf("got agent %q, file_name %q and config\n%s", gotAgent, fileName, config)
+			}
+		})
+	}
+
+	t.Run("platform without agent configuration", func(t *testing.T) {
+		if _, _, _, err := read(t, map[string]interface{}{"source_id": ubuntuID}); !strings.Contains(err, `no agent configuration for platform "ubuntu"`) {
+			t.Errorf("got error %q", err)
+		}
+	})
+	t.Run("missing source", func(t *testing.T) {
+		if _, _, _, err := read(t, map[string]interface{}{"source_id": "404"}); err != "source with ID 404 not found" {
+			t.Errorf("got error %q", err)
+		}
+	})
+}
@@ -2,11 +2,6 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -32,6 +27,9 @@ func newSourceGroupDataSource() *schema.Resource {
 			cp.DefaultFunc = nil
 			cp.DiffSuppressFunc = nil
 		}
+		if k == "team_name" {
+			cp.Description = dataSourceTeamNameDescription
+		}
 		s[k] = &cp
 	}
 
@@ -42,61 +40,15 @@ func newSourceGroupDataSource() *schema.Resource {
 	}
 }
 
-type sourceGroupsHTTPResponse struct {
-	Data []struct {
-		ID         string      `json:"id"`
-		Attributes sourceGroup `json:"attributes"`
-	} `json:"data"`
-	Pagination struct {
-		Next *string `json:"next"`
-	} `json:"pagination"`
-}
-
 func sourceGroupLookup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	name := d.Get("name").(string)
-
-	fetch := func(u string) (*sourceGroupsHTTPResponse, error) {
-		res, err := meta.(*client).Get(ctx, u)
-		if err != nil {
-			return nil, err
-		}
-		defer func() {
-			_, _ = io.Copy(io.Discard, res.Body)
-			_ = res.Body.Close()
-		}()
-		body, err := io.ReadAll(res.Body)
-		if res.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-		}
-		if err != nil {
-			return nil, err
-		}
-		var out sourceGroupsHTTPResponse
-		return &out, json.Unmarshal(body, &out)
-	}
-
-	page := "/api/v1/source-groups?page=1"
-	for {
-		out, err := fetch(page)
+	for item, err := range meta.(*client).SourceGroups().List(ctx) {
 		if err != nil {
 			return diag.FromErr(err)
 		}
-
-		for _, item := range out.Data {
-			if item.Attributes.Name != nil && *item.Attributes.Name == name {
-				d.SetId(item.ID)
-				return sourceGroupCopyAttrs(d, &item.Attributes)
-			}
-		}
-
-		if out.Pagination.Next == nil {
-			break
-		}
-
-		if u, err := url.Parse(*out.Pagination.Next); err != nil {
-			return diag.FromErr(err)
-		} else {
-			page = u.RequestURI()
+		if item.Attributes.Name != nil && *item.Attributes.Name == name {
+			d.SetId(item.ID)
+			return sourceGroupCopyAttrs(d, &item.Attributes)
 		}
 	}
 
@@ -64,6 +64,92 @@ func TestDataMonitor(t *testing.T) {
 	})
 }
 
+func TestDataSources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Log("Received " + r.Method + " " + r.RequestURI)
+
+		if r.Header.Get("Authorization") != "Bearer foo" {
+			t.Fatal("Not authorized: " + r.Header.Get("Authorization"))
+		}
+
+		prefix := "/api/v2/sources"
+
+		switch {
+		case r.Method == http.MethodGet && r.RequestURI == prefix+"?page=1":
+			_, _ = w.Write([]byte(`{"data":[` +
+				`{"id":"1","attributes":{"name":"prod-k8s","token":"token1","table_name":"prod_k8s","team_id":123456,"platform":"kubernetes","data_region":"eu-nbg-2","source_group_id":7}},` +
+				`{"id":"2","attributes":{"name":"prod-ubuntu","token":"token2","table_name":"prod_ubuntu","team_id":123456,"platform":"ubuntu","data_region":"eu-nbg-2","source_group_id":7}}` +
+				`],"pagination":{"next":"..."}}`))
+		case r.Method == http.MethodGet && r.RequestURI == prefix+"?page=2":
+			_, _ = w.Write([]byte(`{"data":[` +
+				`{"id":"3","attributes":{"name":"staging-k8s","token":"token3","table_name":"staging_k8s","team_id":123456,"platform":"kubernetes","data_region":"us-east-9"}},` +
+				`{"id":"4","attributes":{"name":"prod-k8s-eu","token":"token4","table_name":"prod_k8s_eu","team_id":123456,"platform":"kubernetes","data_region":"eu-nbg-2","source_group_id":8}}` +
+				`],"pagination":{"next":null}}`))
+		default:
+			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
+		}
+	}))
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"logtail": func() (*schema.Provider, error) {
+				return New(WithURL(server.URL)), nil
+			},
+		},
+		Steps: []resource.TestStep{
+			// An invalid name_regex is rejected before any request is sent. This step goes first so that
+			// the post-test destroy runs against a valid configuration.
+			{
+				Config: `
+				provider "logtail" {
+					api_token = "foo"
+				}
+
+				data "logtail_sources" "this" {
+					name_regex = "("
+				}
+				`,
+				ExpectError: regexp.MustCompile(`name_regex`),
+			},
+			{
+				Config: `
+				provider "logtail" {
+					api_token = "foo"
+				}
+
+				data "logtail_sources" "all" {
+				}
+
+				data "logtail_sources" "kubernetes" {
+					platform = "kubernetes"
+				}
+
+				data "logtail_sources" "prod_kubernetes_in_group" {
+					platform        = "kubernetes"
+					name_regex      = "^prod-"
+					source_group_id = 7
+					data_region     = "eu-nbg-2"
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.logtail_sources.all", "sources.#", "4"),
+					resource.TestCheckResourceAttr("data.logtail_sources.kubernetes", "sources.#", "3"),
+					resource.TestCheckResourceAttr("data.logtail_sources.kubernetes", "sources.0.table_name", "prod_k8s"),
+					resource.TestCheckResourceAttr("data.logtail_sources.kubernetes", "sources.1.table_name", "staging_k8s"),
+					resource.TestCheckResourceAttr("data.logtail_sources.kubernetes", "sources.2.table_name", "prod_k8s_eu"),
+					resource.TestCheckResourceAttr("data.logtail_sources.prod_kubernetes_in_group", "sources.#", "1"),
+					resource.TestCheckResourceAttr("data.logtail_sources.prod_kubernetes_in_group", "sources.0.id", "1"),
+					resource.TestCheckResourceAttr("data.logtail_sources.prod_kubernetes_in_group", "sources.0.team_id", "123456"),
+					resource.TestCheckResourceAttr("data.logtail_sources.prod_kubernetes_in_group", "sources.0.token", "token1"),
+					resource.TestCheckResourceAttr("data.logtail_sources.prod_kubernetes_in_group", "sources.0.source_group_id", "7"),
+				),
+			},
+		},
+	})
+}
+
 func TestDataSourceGroup(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t.Log("Received " + r.Method + " " + r.RequestURI)
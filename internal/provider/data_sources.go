@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func newSourcesDataSource() *schema.Resource {
+	elem := make(map[string]*schema.Schema)
+	for k, v := range withoutWriteOnly(sourceSchema) {
+		switch k {
+		case "team_name", "custom_bucket":
+			// team_name is create-only input and custom_bucket is never listed with its secret,
+			// use the logtail_source data source to read the bucket configuration of a single source.
+			continue
+		}
+		cp := *v
+		cp.Computed = true
+		cp.Optional = false
+		cp.Required = false
+		cp.ForceNew = false
+		cp.ValidateFunc = nil
+		cp.ValidateDiagFunc = nil
+		cp.Default = nil
+		cp.DefaultFunc = nil
+		cp.DiffSuppressFunc = nil
+		cp.ConflictsWith = nil
+		elem[k] = &cp
+	}
+	return &schema.Resource{
+		ReadContext: sourcesLookup,
+		Description: "This Data Source allows you to list all existing Sources, optionally filtered by platform, name, source group or data region. Use it to `for_each` over existing sources without hardcoding their table names.",
+		Schema: map[string]*schema.Schema{
+			"platform": {
+				Description: "Only return sources of this platform, e.g. `kubernetes`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"name_regex": {
+				Description:  "Only return sources whose name matches this [RE2 regular expression](https://github.com/google/re2/wiki/Syntax).",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"source_group_id": {
+				Description: "Only return sources belonging to the source group with this ID.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"data_region": {
+				Description: "Only return sources stored in this data region. The value is compared with the cluster name returned by the API (for example, `germany` reads back as `eu-nbg-2`).",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"sources": {
+				Description: "The list of matching sources, in the order returned by the API.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Resource{Schema: elem},
+			},
+		},
+	}
+}
+
+func sourcesLookup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		re, err := regexp.Compile(v.(string))
+		if err != nil {
+			return diag.Errorf("invalid name_regex: %v", err)
+		}
+		nameRegex = re
+	}
+	platform := d.Get("platform").(string)
+	dataRegion := d.Get("data_region").(string)
+	sourceGroupID := intFromResourceData(d, "source_group_id")
+
+	sources := make([]interface{}, 0)
+	for e, err := range meta.(*client).Sources().List(ctx) {
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		in := e.Attributes
+		if platform != "" && (in.Platform == nil || *in.Platform != platform) {
+			continue
+		}
+		if nameRegex != nil && (in.Name == nil || !nameRegex.MatchString(*in.Name)) {
+			continue
+		}
+		if dataRegion != "" && (in.DataRegion == nil || *in.DataRegion != dataRegion) {
+			continue
+		}
+		if sourceGroupID != nil && (in.SourceGroupID == nil || *in.SourceGroupID != *sourceGroupID) {
+			continue
+		}
+		sources = append(sources, sourceToMap(e.ID, &in))
+	}
+
+	d.SetId("sources")
+	if err := d.Set("sources", sources); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// sourceToMap flattens a source returned by the API into a map matching the element schema of
+// the logtail_sources data source. Attributes the API omitted are left out of the map.
+func sourceToMap(id string, in *source) map[string]interface{} {
+	out := map[string]interface{}{"id": id}
+	for _, e := range sourceRef(in) {
+		if e.k == "team_id" {
+			if in.TeamId != nil {
+				out[e.k] = in.TeamId.String()
+			}
+			continue
+		}
+		v := reflect.Indirect(reflect.ValueOf(e.v))
+		if v.IsNil() {
+			continue
+		}
+		out[e.k] = v.Elem().Interface()
+	}
+	return out
+}
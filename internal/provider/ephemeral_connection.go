@@ -0,0 +1,297 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// connectionEphemeralResource creates ClickHouse connection credentials for the duration of a
+// single Terraform operation. Unlike the logtail_connection resource, the password never ends up
+// in the plan or state, and the connection is deleted again when Terraform closes it.
+type connectionEphemeralResource struct {
+	client *client
+}
+
+var (
+	_ ephemeral.EphemeralResourceWithConfigure      = (*connectionEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithClose          = (*connectionEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithValidateConfig = (*connectionEphemeralResource)(nil)
+)
+
+// connectionEphemeralPrivateKey stores the connection ID between Open and Close.
+const connectionEphemeralPrivateKey = "connection_id"
+
+func newConnectionEphemeralResource() ephemeral.EphemeralResource {
+	return &connectionEphemeralResource{}
+}
+
+type connectionEphemeralModel struct {
+	ID          types.String `tfsdk:"id"`
+	ClientType  types.String `tfsdk:"client_type"`
+	TeamNames   types.List   `tfsdk:"team_names"`
+	TeamIds     types.List   `tfsdk:"team_ids"`
+	DataRegion  types.String `tfsdk:"data_region"`
+	IpAllowlist types.List   `tfsdk:"ip_allowlist"`
+	ValidUntil  types.String `tfsdk:"valid_until"`
+	Note        types.String `tfsdk:"note"`
+	Host        types.String `tfsdk:"host"`
+	Port        types.Int64  `tfsdk:"port"`
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	SampleQuery types.String `tfsdk:"sample_query"`
+	DataSources types.List   `tfsdk:"data_sources"`
+}
+
+var connectionEphemeralDataSourceType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"source_name":  types.StringType,
+		"source_id":    types.Int64Type,
+		"team_name":    types.StringType,
+		"data_sources": types.ListType{ElemType: types.StringType},
+	},
+}
+
+func (r *connectionEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connection"
+}
+
+// Schema reuses the descriptions of the logtail_connection resource for the shared attributes.
+func (r *connectionEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This ephemeral resource creates short-lived ClickHouse connection credentials for remote querying, e.g. to configure a ClickHouse or Grafana provider. The connection is created whenever Terraform opens the ephemeral resource and deleted when Terraform closes it, the password is never stored in the plan or state. Requires Terraform 1.10 or later. For more information about the Connection API check https://betterstack.com/docs/logs/api/connections/",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: connectionSchema["id"].Description,
+				Computed:    true,
+			},
+			"client_type": schema.StringAttribute{
+				Description: connectionSchema["client_type"].Description,
+				Required:    true,
+			},
+			"team_names": schema.ListAttribute{
+				Description: connectionSchema["team_names"].Description,
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"team_ids": schema.ListAttribute{
+				Description: connectionSchema["team_ids"].Description,
+				ElementType: types.Int64Type,
+				Optional:    true,
+			},
+			"data_region": schema.StringAttribute{
+				Description: connectionSchema["data_region"].Description,
+				Optional:    true,
+			},
+			"ip_allowlist": schema.ListAttribute{
+				Description: connectionSchema["ip_allowlist"].Description,
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"valid_until": schema.StringAttribute{
+				Description: "ISO 8601 timestamp when the connection expires. The connection is deleted when Terraform closes the ephemeral resource, set this to limit its lifetime in case the deletion never happens, e.g. when Terraform is interrupted.",
+				Optional:    true,
+			},
+			"note": schema.StringAttribute{
+				Description: connectionSchema["note"].Description,
+				Optional:    true,
+			},
+			"host": schema.StringAttribute{
+				Description: connectionSchema["host"].Description,
+				Computed:    true,
+			},
+			"port": schema.Int64Attribute{
+				Description: connectionSchema["port"].Description,
+				Computed:    true,
+			},
+			"username": schema.StringAttribute{
+				Description: connectionSchema["username"].Description,
+				Computed:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: "The connection password.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: connectionSchema["created_at"].Description,
+				Computed:    true,
+			},
+			"sample_query": schema.StringAttribute{
+				Description: connectionSchema["sample_query"].Description,
+				Computed:    true,
+			},
+			"data_sources": schema.ListAttribute{
+				Description: connectionSchema["data_sources"].Description,
+				ElementType: connectionEphemeralDataSourceType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *connectionEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *client, got %T.", req.ProviderData))
+		return
+	}
+	r.client = c
+}
+
+func (r *connectionEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var config connectionEphemeralModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.TeamNames.IsNull() && !config.TeamIds.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("team_ids"), "Conflicting team attributes", "Only one of team_names or team_ids should be provided.")
+	}
+	if !config.ValidUntil.IsNull() && !config.ValidUntil.IsUnknown() {
+		if _, err := time.Parse(time.RFC3339, config.ValidUntil.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("valid_until"), "Invalid valid_until", fmt.Sprintf("valid_until must be an ISO 8601 timestamp, e.g. 2026-01-01T00:00:00Z: %v", err))
+		}
+	}
+}
+
+func (r *connectionEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Unconfigured provider", "The provider must be configured with an api_token before logtail_connection can be opened.")
+		return
+	}
+
+	var config connectionEphemeralModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	in := connection{
+		ClientType: config.ClientType.ValueStringPointer(),
+		DataRegion: config.DataRegion.ValueStringPointer(),
+		ValidUntil: config.ValidUntil.ValueStringPointer(),
+		Note:       config.Note.ValueStringPointer(),
+	}
+	if !config.TeamNames.IsNull() {
+		var teamNames []string
+		resp.Diagnostics.Append(config.TeamNames.ElementsAs(ctx, &teamNames, false)...)
+		in.TeamNames = &teamNames
+	}
+	if !config.TeamIds.IsNull() {
+		var teamIds []int
+		resp.Diagnostics.Append(config.TeamIds.ElementsAs(ctx, &teamIds, false)...)
+		in.TeamIds = &teamIds
+	}
+	if !config.IpAllowlist.IsNull() {
+		var ipAllowlist []string
+		resp.Diagnostics.Append(config.IpAllowlist.ElementsAs(ctx, &ipAllowlist, false)...)
+		in.IpAllowlist = &ipAllowlist
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var out connectionHTTPResponse
+	ctx = betterstack.WithResource(ctx, "ephemeral.logtail_connection", "")
+	if derr := resourceCreate(ctx, r.client, "/api/v1/connections", &in, &out); derr != nil {
+		resp.Diagnostics.Append(frameworkDiagnostics(derr)...)
+		return
+	}
+	// Record the ID first, so the connection is deleted on close even if reading the response fails.
+	// Private state values must be JSON.
+	id, _ := json.Marshal(out.Data.ID)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, connectionEphemeralPrivateKey, id)...)
+
+	attrs := out.Data.Attributes
+	config.ID = types.StringValue(out.Data.ID)
+	config.Host = types.StringPointerValue(attrs.Host)
+	config.Port = types.Int64Null()
+	if attrs.Port != nil {
+		config.Port = types.Int64Value(int64(*attrs.Port))
+	}
+	config.Username = types.StringPointerValue(attrs.Username)
+	config.Password = types.StringPointerValue(attrs.Password)
+	config.CreatedAt = types.StringPointerValue(attrs.CreatedAt)
+	config.SampleQuery = types.StringPointerValue(attrs.SampleQuery)
+	dataSources, diags := connectionEphemeralDataSources(attrs.DataSources)
+	resp.Diagnostics.Append(diags...)
+	config.DataSources = dataSources
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &config)...)
+}
+
+func (r *connectionEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	raw, diags := req.Private.GetKey(ctx, connectionEphemeralPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || raw == nil {
+		return
+	}
+	var id string
+	if err := json.Unmarshal(raw, &id); err != nil {
+		resp.Diagnostics.AddError("Unable to read connection ID", err.Error())
+		return
+	}
+	if r.client == nil {
+		resp.Diagnostics.AddError("Unconfigured provider", fmt.Sprintf("Unable to delete connection %s, the provider is not configured.", id))
+		return
+	}
+	ctx = betterstack.WithResource(ctx, "ephemeral.logtail_connection", id)
+	resp.Diagnostics.Append(frameworkDiagnostics(resourceDelete(ctx, r.client, fmt.Sprintf("/api/v1/connections/%s", url.PathEscape(id))))...)
+}
+
+// connectionEphemeralDataSources converts the data_sources returned by the API. Like the
+// resource, it ignores any keys the schema does not declare.
+func connectionEphemeralDataSources(in *[]map[string]interface{}) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	values := make([]attr.Value, 0)
+	if in != nil {
+		for _, e := range *in {
+			sourceName, _ := e["source_name"].(string)
+			teamName, _ := e["team_name"].(string)
+			sourceID := types.Int64Null()
+			if v, ok := e["source_id"].(float64); ok {
+				sourceID = types.Int64Value(int64(v))
+			}
+			dataSources := make([]attr.Value, 0)
+			if v, ok := e["data_sources"].([]interface{}); ok {
+				for _, ds := range v {
+					if s, ok := ds.(string); ok {
+						dataSources = append(dataSources, types.StringValue(s))
+					}
+				}
+			}
+			dataSourcesList, d := types.ListValue(types.StringType, dataSources)
+			diags.Append(d...)
+			obj, d := types.ObjectValue(connectionEphemeralDataSourceType.AttrTypes, map[string]attr.Value{
+				"source_name":  types.StringValue(sourceName),
+				"source_id":    sourceID,
+				"team_name":    types.StringValue(teamName),
+				"data_sources": dataSourcesList,
+			})
+			diags.Append(d...)
+			values = append(values, obj)
+		}
+	}
+	list, d := types.ListValue(connectionEphemeralDataSourceType, values)
+	diags.Append(d...)
+	return list, diags
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestEphemeralConnection(t *testing.T) {
+	var mu sync.Mutex
+	var created, deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer foo" {
+			t.Fatal("Not authorized: " + r.Header.Get("Authorization"))
+		}
+		mu.Lock()
+		defer mu.Unlock()
+
+		prefix := "/api/v1/connections"
+
+		switch {
+		case r.Method == http.MethodPost && r.RequestURI == prefix:
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var in connection
+			if err := json.Unmarshal(body, &in); err != nil {
+				t.Fatal(err)
+			}
+			if in.ValidUntil == nil || *in.ValidUntil != "2030-01-01T00:00:00Z" {
+				t.Errorf("Expected valid_until to be sent, got %s", body)
+			}
+			if in.IpAllowlist == nil || len(*in.IpAllowlist) != 1 || (*in.IpAllowlist)[0] != "10.0.0.0/8" {
+				t.Errorf("Expected ip_allowlist to be sent, got %s", body)
+			}
+			id := fmt.Sprint(len(created) + 1)
+			created = append(created, id)
+			body = inject(t, body, "host", "us-east-9-connect.betterstackdata.com")
+			body = inject(t, body, "port", 443)
+			body = inject(t, body, "username", "u"+id)
+			body = inject(t, body, "password", "secret"+id)
+			body = inject(t, body, "data_sources", []interface{}{
+				map[string]interface{}{"source_name": "Production", "source_id": 1, "team_name": "Team", "data_sources": []string{"t1_production_logs"}},
+			})
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"data":{"id":%q,"attributes":%s}}`, id, body)))
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.RequestURI[len(prefix)+1:])
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
+		}
+	}))
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"logtail": func() (tfprotov6.ProviderServer, error) {
+				muxServer, err := NewMuxServer(context.Background(), WithURL(server.URL))
+				if err != nil {
+					return nil, err
+				}
+				return muxServer(), nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "logtail" {
+					api_token = "foo"
+				}
+
+				ephemeral "logtail_connection" "this" {
+					client_type  = "clickhouse"
+					ip_allowlist = ["10.0.0.0/8"]
+					valid_until  = "2030-01-01T00:00:00Z"
+				}
+
+				locals {
+					dsn = "https://${ephemeral.logtail_connection.this.username}:${ephemeral.logtail_connection.this.password}@${ephemeral.logtail_connection.this.host}"
+				}
+				`,
+			},
+		},
+	})
+
+	// Every connection opened during plan and apply must have been deleted on close.
+	if len(created) == 0 {
+		t.Fatal("Expected the ephemeral connection to be opened")
+	}
+	if fmt.Sprint(created) != fmt.Sprint(deleted) {
+		t.Errorf("Expected connections %v to be deleted, got %v", created, deleted)
+	}
+}
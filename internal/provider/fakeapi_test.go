@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/betterstackhq/terraform-provider-logtail/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestFakeAPILifecycle runs the full lifecycle of every resource served by the in-memory fake API,
+// depending on each other.
+func TestFakeAPILifecycle(t *testing.T) {
+	api := fakeapi.New(t)
+
+	config := func(groupName string, retention int) string {
+		return fmt.Sprintf(`
+		provider "logtail" {
+			api_token = "foo"
+		}
+
+		resource "logtail_source_group" "this" {
+			name = %q
+		}
+
+		resource "logtail_source" "this" {
+			name                    = "Production"
+			platform                = "kubernetes"
+			source_group_id         = logtail_source_group.this.id
+			logs_retention          = %d
+			vrl_transformation_logs = ".env = \"production\""
+		}
+
+		resource "logtail_metric" "this" {
+			source_id      = logtail_source.this.id
+			name           = "level"
+			sql_expression = "JSONExtract(raw, 'level', 'Nullable(String)')"
+		}
+
+		resource "logtail_dashboard" "this" {
+			name = "Overview"
+			data = jsonencode({ name = "Overview", charts = [] })
+		}
+
+		resource "logtail_dashboard_section" "this" {
+			dashboard_id = logtail_dashboard.this.id
+			name         = "Requests"
+			y            = 0
+		}
+
+		resource "logtail_collector" "this" {
+			name     = "Cluster"
+			platform = "kubernetes"
+		}
+
+		resource "logtail_source_aws_log_group" "this" {
+			source_id = logtail_source.this.id
+			region    = "us-east-1"
+			name      = "/aws/lambda/backend"
+		}
+
+		resource "logtail_dashboard_group" "this" {
+			name = "Production"
+		}
+
+		resource "logtail_dashboard" "charts" {
+			name               = "Requests"
+			dashboard_group_id = logtail_dashboard_group.this.id
+		}
+
+		resource "logtail_dashboard_chart" "this" {
+			dashboard_id = logtail_dashboard.charts.id
+			chart_type   = "line_chart"
+			name         = "Request rate"
+
+			query {
+				query_type = "sql_expression"
+				sql_query  = "SELECT {{time}} AS time, count(*) AS value FROM {{source}} WHERE time BETWEEN {{start_time}} AND {{end_time}} GROUP BY time"
+			}
+		}
+
+		resource "logtail_dashboard_alert" "this" {
+			dashboard_id = logtail_dashboard.charts.id
+			chart_id     = logtail_dashboard_chart.this.id
+			name         = "High request rate"
+			alert_type   = "threshold"
+			operator     = "higher_than"
+			value        = 100
+			check_period = 60
+			query_period = 300
+			email        = true
+		}
+
+		resource "logtail_exploration_group" "this" {
+			name = "Production"
+		}
+
+		resource "logtail_exploration" "this" {
+			name                 = "Requests by status"
+			exploration_group_id = logtail_exploration_group.this.id
+
+			chart {
+				chart_type = "bar_chart"
+			}
+
+			query {
+				query_type = "sql_expression"
+				sql_query  = "SELECT {{time}} AS time, count(*) AS value FROM {{source}} WHERE time BETWEEN {{start_time}} AND {{end_time}} GROUP BY time"
+			}
+
+			variable {
+				name          = "source"
+				variable_type = "source"
+				values        = [logtail_source.this.id]
+			}
+		}
+
+		resource "logtail_exploration_alert" "this" {
+			exploration_id = logtail_exploration.this.id
+			name           = "Too many requests"
+			alert_type     = "threshold"
+			operator       = "higher_than"
+			value          = 100
+			check_period   = 60
+			query_period   = 300
+			email          = true
+		}
+
+		resource "logtail_collector_target" "this" {
+			collector_id        = logtail_collector.this.id
+			kind                = "postgres"
+			host                = "db.example.com"
+			port                = 5432
+			username            = "monitor"
+			password_wo         = "secret"
+			password_wo_version = 1
+			ssl_mode            = "require"
+		}
+
+		resource "logtail_connection" "this" {
+			client_type = "clickhouse"
+			team_ids    = [123456]
+			note        = "Analytics"
+		}
+
+		resource "logtail_errors_application_group" "this" {
+			name = "Backend"
+		}
+
+		resource "logtail_errors_application" "this" {
+			name                 = "Backend"
+			platform             = "ruby_errors"
+			application_group_id = logtail_errors_application_group.this.id
+		}
+		`, groupName, retention)
+	}
+	importStep := func(name string, ignore ...string) resource.TestStep {
+		return resource.TestStep{
+			ResourceName:            name,
+			ImportState:             true,
+			ImportStateVerify:       true,
+			ImportStateVerifyIgnore: ignore,
+		}
+	}
+	// childImportStep imports a resource nested under a parent by "<parent ID><sep><ID>".
+	childImportStep := func(name, parentAttr, sep string, ignore ...string) resource.TestStep {
+		step := importStep(name, ignore...)
+		step.ImportStateIdFunc = func(s *terraform.State) (string, error) {
+			rs := s.RootModule().Resources[name]
+			return rs.Primary.Attributes[parentAttr] + sep + rs.Primary.ID, nil
+		}
+		return step
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"logtail": func() (*schema.Provider, error) {
+				return New(WithURL(api.URL)), nil
+			},
+		},
+		// Destroying the resources must leave nothing behind in the fake.
+		CheckDestroy: func(s *terraform.State) error {
+			if paths := api.Paths(); len(paths) != 0 {
+				return fmt.Errorf("resources left after destroy: %v", paths)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("Services", 30),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("logtail_source.this", "data_region", fakeapi.DefaultDataRegion),
+					resource.TestCheckResourceAttr("logtail_source.this", "table_name", "production"),
+					resource.TestCheckResourceAttrSet("logtail_source.this", "token"),
+					resource.TestCheckResourceAttrSet("logtail_collector.this", "secret"),
+					resource.TestCheckResourceAttrSet("logtail_errors_application.this", "ingesting_host"),
+				),
+			},
+			{
+				Config: config("Backend services", 60),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("logtail_source_group.this", "name", "Backend services"),
+					resource.TestCheckResourceAttr("logtail_source.this", "logs_retention", "60"),
+				),
+			},
+			importStep("logtail_source_group.this"),
+			importStep("logtail_source.this"),
+			childImportStep("logtail_metric.this", "source_id", "/"),
+			childImportStep("logtail_source_aws_log_group.this", "source_id", "/"),
+			// A dashboard created from data is imported without it, and is then read like a
+			// dashboard managed by its individual fields.
+			importStep("logtail_dashboard.this", "data", "date_range_from", "date_range_to", "refresh_interval", "source_eligibility_sql"),
+			importStep("logtail_dashboard_section.this"),
+			importStep("logtail_dashboard_group.this"),
+			importStep("logtail_dashboard.charts"),
+			importStep("logtail_dashboard_chart.this"),
+			importStep("logtail_dashboard_alert.this"),
+			importStep("logtail_exploration_group.this"),
+			importStep("logtail_exploration.this"),
+			importStep("logtail_exploration_alert.this"),
+			importStep("logtail_collector.this"),
+			// The write-only password version isn't returned by the API.
+			childImportStep("logtail_collector_target.this", "collector_id", ":", "password_wo_version"),
+			// logtail_connection can't be imported, its password is only returned on create.
+			importStep("logtail_errors_application_group.this"),
+			importStep("logtail_errors_application.this"),
+		},
+	})
+}
@@ -0,0 +1,309 @@
+package provider
+
+import (
+	"context"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	sdkdiag "github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// frameworkProvider serves the resources and data sources written with terraform-plugin-framework.
+// It runs next to the SDKv2 provider returned by New, muxed into a single provider server by
+// NewMuxServer, so both must declare exactly the same provider schema.
+type frameworkProvider struct {
+	spec provider
+}
+
+var (
+	_ fwprovider.ProviderWithEphemeralResources = (*frameworkProvider)(nil)
+	_ fwprovider.ProviderWithFunctions          = (*frameworkProvider)(nil)
+)
+
+// NewFramework returns the terraform-plugin-framework half of the provider.
+func NewFramework(opts ...Option) fwprovider.Provider {
+	spec := provider{
+		url: "https://telemetry.betterstack.com",
+	}
+	for _, opt := range opts {
+		opt(&spec)
+	}
+	return &frameworkProvider{spec: spec}
+}
+
+// NewMuxServer combines the SDKv2 provider (upgraded to protocol version 6) and the framework
+// provider into a single provider server. Both halves are configured from the same provider block
+// and share one API client.
+func NewMuxServer(ctx context.Context, opts ...Option) (func() tfprotov6.ProviderServer, error) {
+	opts = append(opts, withSharedClient(&sharedClient{}))
+	upgradedSDKServer, err := tf5to6server.UpgradeServer(ctx, New(opts...).GRPCProvider)
+	if err != nil {
+		return nil, err
+	}
+	muxServer, err := tf6muxserver.NewMuxServer(ctx,
+		func() tfprotov6.ProviderServer { return upgradedSDKServer },
+		providerserver.NewProtocol6(NewFramework(opts...)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
+
+type frameworkProviderModel struct {
+	APIToken           types.String `tfsdk:"api_token"`
+	APITokenFile       types.String `tfsdk:"api_token_file"`
+	APITokenCommand    types.List   `tfsdk:"api_token_command"`
+	ErrorsAPIToken     types.String `tfsdk:"errors_api_token"`
+	APIRetryMax        types.Int64  `tfsdk:"api_retry_max"`
+	APIRetryWaitMin    types.Int64  `tfsdk:"api_retry_wait_min"`
+	APIRetryWaitMax    types.Int64  `tfsdk:"api_retry_wait_max"`
+	APITimeout         types.Int64  `tfsdk:"api_timeout"`
+	APIRateLimit       types.Int64  `tfsdk:"api_rate_limit"`
+	APIRateBurst       types.Int64  `tfsdk:"api_rate_burst"`
+	APICache           types.Bool   `tfsdk:"api_cache"`
+	DefaultTeamName    types.String `tfsdk:"default_team_name"`
+	TelemetryURL       types.String `tfsdk:"telemetry_url"`
+	ErrorsURL          types.String `tfsdk:"errors_url"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	ClientCertPEM      types.String `tfsdk:"client_cert_pem"`
+	ClientKeyPEM       types.String `tfsdk:"client_key_pem"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ReadOnly           types.Bool   `tfsdk:"read_only"`
+	AuditLogPath       types.String `tfsdk:"audit_log_path"`
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "logtail"
+	resp.Version = p.spec.version
+}
+
+// Schema mirrors the schema of the SDKv2 provider - descriptions included, the mux server rejects
+// any difference.
+func (p *frameworkProvider) Schema(ctx context.Context, req fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	sdkSchema := providerSchema()
+	resp.Schema = providerschema.Schema{
+		Attributes: map[string]providerschema.Attribute{
+			"api_token": providerschema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: sdkSchema["api_token"].Description,
+			},
+			"api_token_file": providerschema.StringAttribute{
+				Optional:    true,
+				Description: sdkSchema["api_token_file"].Description,
+			},
+			"api_token_command": providerschema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: sdkSchema["api_token_command"].Description,
+			},
+			"errors_api_token": providerschema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: sdkSchema["errors_api_token"].Description,
+			},
+			"api_retry_max": providerschema.Int64Attribute{
+				Optional:    true,
+				Description: sdkSchema["api_retry_max"].Description,
+			},
+			"api_retry_wait_min": providerschema.Int64Attribute{
+				Optional:    true,
+				Description: sdkSchema["api_retry_wait_min"].Description,
+			},
+			"api_retry_wait_max": providerschema.Int64Attribute{
+				Optional:    true,
+				Description: sdkSchema["api_retry_wait_max"].Description,
+			},
+			"api_timeout": providerschema.Int64Attribute{
+				Optional:    true,
+				Description: sdkSchema["api_timeout"].Description,
+			},
+			"api_rate_limit": providerschema.Int64Attribute{
+				Optional:    true,
+				Description: sdkSchema["api_rate_limit"].Description,
+			},
+			"api_rate_burst": providerschema.Int64Attribute{
+				Optional:    true,
+				Description: sdkSchema["api_rate_burst"].Description,
+			},
+			"api_cache": providerschema.BoolAttribute{
+				Optional:    true,
+				Description: sdkSchema["api_cache"].Description,
+			},
+			"default_team_name": providerschema.StringAttribute{
+				Optional:    true,
+				Description: sdkSchema["default_team_name"].Description,
+			},
+			"telemetry_url": providerschema.StringAttribute{
+				Optional:    true,
+				Description: sdkSchema["telemetry_url"].Description,
+			},
+			"errors_url": providerschema.StringAttribute{
+				Optional:    true,
+				Description: sdkSchema["errors_url"].Description,
+			},
+			"proxy_url": providerschema.StringAttribute{
+				Optional:    true,
+				Description: sdkSchema["proxy_url"].Description,
+			},
+			"ca_cert_pem": providerschema.StringAttribute{
+				Optional:    true,
+				Description: sdkSchema["ca_cert_pem"].Description,
+			},
+			"ca_cert_file": providerschema.StringAttribute{
+				Optional:    true,
+				Description: sdkSchema["ca_cert_file"].Description,
+			},
+			"client_cert_pem": providerschema.StringAttribute{
+				Optional:    true,
+				Description: sdkSchema["client_cert_pem"].Description,
+			},
+			"client_key_pem": providerschema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: sdkSchema["client_key_pem"].Description,
+			},
+			"insecure_skip_verify": providerschema.BoolAttribute{
+				Optional:    true,
+				Description: sdkSchema["insecure_skip_verify"].Description,
+			},
+			"read_only": providerschema.BoolAttribute{
+				Optional:    true,
+				Description: sdkSchema["read_only"].Description,
+			},
+			"audit_log_path": providerschema.StringAttribute{
+				Optional:    true,
+				Description: sdkSchema["audit_log_path"].Description,
+			},
+		},
+	}
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req fwprovider.ConfigureRequest, resp *fwprovider.ConfigureResponse) {
+	var config frameworkProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.APIToken.IsUnknown() || config.APITokenFile.IsUnknown() || config.APITokenCommand.IsUnknown() {
+		// Framework resources will complain about the unconfigured provider if they are used.
+		return
+	}
+	token := stringOrEnv(config.APIToken, "LOGTAIL_API_TOKEN")
+	var tokenCommand []string
+	resp.Diagnostics.Append(config.APITokenCommand.ElementsAs(ctx, &tokenCommand, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tokenFile := apiTokenFile(config.APITokenFile.ValueString(), !config.APIToken.IsNull() || len(tokenCommand) > 0)
+	if token == "" && tokenFile == "" && len(tokenCommand) == 0 {
+		// The SDKv2 provider reports a missing token, no need to report it twice.
+		return
+	}
+
+	c, err := p.spec.newClient(providerConfig{
+		APIToken:           token,
+		APITokenFile:       tokenFile,
+		APITokenCommand:    tokenCommand,
+		ErrorsAPIToken:     stringOrEnv(config.ErrorsAPIToken, "LOGTAIL_ERRORS_API_TOKEN"),
+		APIRetryMax:        int64OrDefault(config.APIRetryMax, defaultAPIRetryMax),
+		APIRetryWaitMin:    int64OrDefault(config.APIRetryWaitMin, defaultAPIRetryWaitMin),
+		APIRetryWaitMax:    int64OrDefault(config.APIRetryWaitMax, defaultAPIRetryWaitMax),
+		APITimeout:         int64OrDefault(config.APITimeout, defaultAPITimeout),
+		APIRateLimit:       int64OrDefault(config.APIRateLimit, defaultAPIRateLimit),
+		APIRateBurst:       int64OrDefault(config.APIRateBurst, defaultAPIRateBurst),
+		APICache:           boolOrDefault(config.APICache, defaultAPICache),
+		DefaultTeamName:    stringOrEnv(config.DefaultTeamName, "LOGTAIL_DEFAULT_TEAM_NAME"),
+		TelemetryURL:       stringOrEnv(config.TelemetryURL, "LOGTAIL_TELEMETRY_URL"),
+		ErrorsURL:          stringOrEnv(config.ErrorsURL, "LOGTAIL_ERRORS_URL"),
+		ProxyURL:           config.ProxyURL.ValueString(),
+		CACertPEM:          config.CACertPEM.ValueString(),
+		CACertFile:         config.CACertFile.ValueString(),
+		ClientCertPEM:      config.ClientCertPEM.ValueString(),
+		ClientKeyPEM:       config.ClientKeyPEM.ValueString(),
+		InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+		ReadOnly:           config.ReadOnly.ValueBool(),
+		AuditLogPath:       config.AuditLogPath.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create Better Stack API client", err.Error())
+		return
+	}
+	resp.DataSourceData = c
+	resp.ResourceData = c
+	resp.EphemeralResourceData = c
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newConnectionEphemeralResource,
+	}
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		newNormalizeVRLFunction,
+		newDashboardJSONNormalizeFunction,
+		newSourceRefFunction,
+		newParseCompositeIDFunction,
+	}
+}
+
+// frameworkDiagnostics converts SDKv2 diagnostics, e.g. returned by resourceCreate, so the
+// framework resources can share the request helpers of the SDKv2 ones.
+func frameworkDiagnostics(in sdkdiag.Diagnostics) diag.Diagnostics {
+	var out diag.Diagnostics
+	for _, d := range in {
+		if d.Severity == sdkdiag.Warning {
+			out.AddWarning(d.Summary, d.Detail)
+		} else {
+			out.AddError(d.Summary, d.Detail)
+		}
+	}
+	return out
+}
+
+// stringOrEnv mirrors schema.EnvDefaultFunc of the SDKv2 provider schema.
+func stringOrEnv(v types.String, key string) string {
+	if v.IsNull() {
+		return os.Getenv(key)
+	}
+	return v.ValueString()
+}
+
+func int64OrDefault(v types.Int64, def int) int {
+	if v.IsNull() || v.IsUnknown() {
+		return def
+	}
+	return int(v.ValueInt64())
+}
+
+func boolOrDefault(v types.Bool, def bool) bool {
+	if v.IsNull() || v.IsUnknown() {
+		return def
+	}
+	return v.ValueBool()
+}
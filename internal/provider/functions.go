@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The provider functions, available as provider::logtail::<name> since Terraform 1.8. They only
+// transform their arguments and never call the API.
+
+var (
+	_ function.Function = (*normalizeVRLFunction)(nil)
+	_ function.Function = (*dashboardJSONNormalizeFunction)(nil)
+	_ function.Function = (*sourceRefFunction)(nil)
+	_ function.Function = (*parseCompositeIDFunction)(nil)
+)
+
+// normalizeVRLFunction exposes normalizeVRL, so configurations can compare a VRL program with
+// the one read from the API like the provider does.
+type normalizeVRLFunction struct{}
+
+func newNormalizeVRLFunction() function.Function {
+	return &normalizeVRLFunction{}
+}
+
+func (f *normalizeVRLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_vrl"
+}
+
+func (f *normalizeVRLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Normalize a VRL program",
+		Description: "Returns the VRL program as the provider compares it: surrounding whitespace, blank lines and trailing dots are removed from each line. The API stores VRL programs with a trailing `\\n.`, so a program read back from it normalizes to the same value as the configured one.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "vrl",
+				Description: "The VRL program.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *normalizeVRLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var vrl string
+	resp.Error = req.Arguments.Get(ctx, &vrl)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, normalizeVRL(vrl))
+}
+
+// dashboardJSONNormalizeFunction normalizes the data of a logtail_dashboard, e.g. an exported
+// dashboard committed to the repository, to the form of the dashboard data sources.
+type dashboardJSONNormalizeFunction struct{}
+
+func newDashboardJSONNormalizeFunction() function.Function {
+	return &dashboardJSONNormalizeFunction{}
+}
+
+func (f *dashboardJSONNormalizeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "dashboard_json_normalize"
+}
+
+func (f *dashboardJSONNormalizeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Normalize dashboard JSON",
+		Description: "Returns the dashboard JSON in the form the `logtail_dashboard` and `logtail_dashboard_template` data sources return their `data`: compact, with object keys sorted. As any change of the `data` attribute of `logtail_dashboard` re-creates the dashboard, normalizing it keeps formatting changes of an exported file from doing so.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "json",
+				Description: "The dashboard JSON, e.g. the contents of an exported dashboard file.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *dashboardJSONNormalizeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var data string
+	resp.Error = req.Arguments.Get(ctx, &data)
+	if resp.Error != nil {
+		return
+	}
+	normalized, err := normalizeDashboardJSON(data)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, normalized)
+}
+
+// normalizeDashboardJSON re-encodes dashboard JSON like the data sources encode the export of a
+// dashboard.
+func normalizeDashboardJSON(data string) (string, error) {
+	var v map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		return "", fmt.Errorf("invalid dashboard JSON: %w", err)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// sourceRefFunction returns the reference to a source used by source_variable of queries and
+// alerts.
+type sourceRefFunction struct{}
+
+func newSourceRefFunction() function.Function {
+	return &sourceRefFunction{}
+}
+
+func (f *sourceRefFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "source_ref"
+}
+
+func (f *sourceRefFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Reference a source",
+		Description: "Returns the `source:table_name` reference to a source used by `source_variable` of dashboard and exploration alerts, e.g. `provider::logtail::source_ref(logtail_source.this.table_name)`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "table_name",
+				Description: "The `table_name` of the source.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *sourceRefFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var tableName string
+	resp.Error = req.Arguments.Get(ctx, &tableName)
+	if resp.Error != nil {
+		return
+	}
+	ref, err := sourceVariableRef(tableName)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, ref)
+}
+
+func sourceVariableRef(tableName string) (string, error) {
+	tableName = strings.TrimSpace(tableName)
+	if tableName == "" {
+		return "", fmt.Errorf("table_name must not be empty")
+	}
+	if strings.HasPrefix(tableName, "source:") {
+		return "", fmt.Errorf("table_name %q is already a source reference", tableName)
+	}
+	return "source:" + tableName, nil
+}
+
+// parseCompositeIDFunction splits the IDs of nested resources, e.g. of logtail_dashboard_alert,
+// into the IDs of the resource and its parents.
+type parseCompositeIDFunction struct{}
+
+func newParseCompositeIDFunction() function.Function {
+	return &parseCompositeIDFunction{}
+}
+
+func (f *parseCompositeIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_composite_id"
+}
+
+func (f *parseCompositeIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Split a composite ID",
+		Description: "Splits the ID of a nested resource, e.g. `dashboard_id/chart_id/alert_id` of `logtail_dashboard_alert` or `source_id/metric_id` of an imported `logtail_metric`, into the list of its parts, parents first.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The composite ID, with its parts separated by `/`.",
+			},
+		},
+		Return: function.ListReturn{ElementType: types.StringType},
+	}
+}
+
+func (f *parseCompositeIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = req.Arguments.Get(ctx, &id)
+	if resp.Error != nil {
+		return
+	}
+	parts, err := parseCompositeID(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, parts)
+}
+
+func parseCompositeID(id string) ([]string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid composite ID %q, expected IDs separated by '/', e.g. 'dashboard_id/chart_id/alert_id'", id)
+	}
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("invalid composite ID %q, expected IDs separated by '/', e.g. 'dashboard_id/chart_id/alert_id'", id)
+		}
+	}
+	return parts, nil
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func callFunction(t *testing.T, name, arg string, resultType tftypes.Type) (tftypes.Value, *tfprotov6.FunctionError) {
+	t.Helper()
+	ctx := context.Background()
+	muxServer, err := NewMuxServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dv, err := tfprotov6.NewDynamicValue(tftypes.String, tftypes.NewValue(tftypes.String, arg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := muxServer().CallFunction(ctx, &tfprotov6.CallFunctionRequest{Name: name, Arguments: []*tfprotov6.DynamicValue{&dv}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error != nil {
+		return tftypes.Value{}, resp.Error
+	}
+	result, err := resp.Result.Unmarshal(resultType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result, nil
+}
+
+func TestFunctions(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		want    interface{}
+		wantErr string
+	}{
+		{name: "normalize_vrl", arg: "  .a = 1\n\n.b = 2\n.\n", want: ".a = 1\n.b = 2"},
+		{name: "dashboard_json_normalize", arg: "{\n  \"b\": [1, 2.5],\n  \"a\": {\"d\": null, \"c\": \"x\"}\n}", want: `{"a":{"c":"x","d":null},"b":[1,2.5]}`},
+		{name: "dashboard_json_normalize", arg: `{"a":`, wantErr: "invalid dashboard JSON"},
+		{name: "dashboard_json_normalize", arg: `[]`, wantErr: "invalid dashboard JSON"},
+		{name: "source_ref", arg: "t123_logs", want: "source:t123_logs"},
+		{name: "source_ref", arg: " ", wantErr: "must not be empty"},
+		{name: "source_ref", arg: "source:t123_logs", wantErr: "already a source reference"},
+		{name: "parse_composite_id", arg: "1/2/3", want: []string{"1", "2", "3"}},
+		{name: "parse_composite_id", arg: "1/2", want: []string{"1", "2"}},
+		{name: "parse_composite_id", arg: "1", wantErr: "invalid composite ID"},
+		{name: "parse_composite_id", arg: "1//3", wantErr: "invalid composite ID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.arg, func(t *testing.T) {
+			var resultType tftypes.Type = tftypes.String
+			if tt.name == "parse_composite_id" {
+				resultType = tftypes.List{ElementType: tftypes.String}
+			}
+			result, funcErr := callFunction(t, tt.name, tt.arg, resultType)
+			if tt.wantErr != "" {
+				if funcErr == nil || !strings.Contains(funcErr.Text, tt.wantErr) {
+					t.Fatalf("got error %v, want %q", funcErr, tt.wantErr)
+				}
+				return
+			}
+			if funcErr != nil {
+				t.Fatal(funcErr.Text)
+			}
+			var got interface{}
+			switch tt.want.(type) {
+			case string:
+				var s string
+				if err := result.As(&s); err != nil {
+					t.Fatal(err)
+				}
+				got = s
+			case []string:
+				var values []tftypes.Value
+				if err := result.As(&values); err != nil {
+					t.Fatal(err)
+				}
+				parts := []string{}
+				for _, v := range values {
+					var s string
+					if err := v.As(&s); err != nil {
+						t.Fatal(err)
+					}
+					parts = append(parts, s)
+				}
+				got = parts
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,577 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// GenerateOptions configures Generate.
+type GenerateOptions struct {
+	// OutputDir is the directory the .tf files are written to. Existing files are never overwritten.
+	OutputDir string
+	// ResourceTypes limits the export to the given resource types. All of GenerateResourceTypes are
+	// exported if empty.
+	ResourceTypes []string
+	// Log receives progress messages and warnings about resources that couldn't be exported.
+	Log io.Writer
+}
+
+// GenerateResourceTypes are the resource types Generate can export, parents before children.
+var GenerateResourceTypes = []string{
+	"logtail_source_group",
+	"logtail_source",
+	"logtail_metric",
+	"logtail_collector",
+	"logtail_dashboard_group",
+	"logtail_dashboard",
+	"logtail_dashboard_section",
+	"logtail_dashboard_chart",
+	"logtail_dashboard_alert",
+	"logtail_exploration_group",
+	"logtail_exploration",
+	"logtail_exploration_alert",
+}
+
+// generateReferences maps the attributes holding the ID of another resource to its type. Exported
+// values are replaced by a reference, e.g. `source_group_id = logtail_source_group.production.id`.
+var generateReferences = map[string]string{
+	"source_group_id":      "logtail_source_group",
+	"source_id":            "logtail_source",
+	"dashboard_group_id":   "logtail_dashboard_group",
+	"dashboard_id":         "logtail_dashboard",
+	"exploration_group_id": "logtail_exploration_group",
+	"exploration_id":       "logtail_exploration",
+}
+
+// Generate exports the resources of the account the provider is configured for to Terraform
+// configuration: a `<resource type>.tf` file per resource type and `imports.tf` with an import
+// block for every resource, so a `terraform plan` in OutputDir adopts them instead of creating
+// duplicates. The provider is configured from its environment variables, e.g. LOGTAIL_API_TOKEN.
+//
+// Every resource is read the same way `terraform import` would, through the importer and read
+// function of the resource. Computed-only, deprecated and sensitive attributes aren't exported,
+// sensitive attributes with a value are listed in a comment to be filled in manually.
+func Generate(ctx context.Context, opts GenerateOptions, providerOpts ...Option) error {
+	p := New(providerOpts...)
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{})
+	if diags := p.Validate(config); diags.HasError() {
+		return generateDiagnosticsError(diags)
+	}
+	if diags := p.Configure(ctx, config); diags.HasError() {
+		return generateDiagnosticsError(diags)
+	}
+
+	g := &generator{
+		provider:  p,
+		client:    p.Meta().(*client),
+		types:     make(map[string]bool),
+		resources: make(map[string][]*generatedResource),
+		names:     make(map[string]bool),
+		log:       opts.Log,
+	}
+	if g.log == nil {
+		g.log = io.Discard
+	}
+	for _, t := range opts.ResourceTypes {
+		if _, ok := p.ResourcesMap[t]; !ok || !generateSupported(t) {
+			return fmt.Errorf("unsupported resource type %q, supported types: %s", t, strings.Join(GenerateResourceTypes, ", "))
+		}
+		g.types[t] = true
+	}
+	if len(g.types) == 0 {
+		for _, t := range GenerateResourceTypes {
+			g.types[t] = true
+		}
+	}
+
+	if err := g.discover(ctx); err != nil {
+		return err
+	}
+	return g.write(opts.OutputDir)
+}
+
+func generateSupported(resourceType string) bool {
+	for _, t := range GenerateResourceTypes {
+		if t == resourceType {
+			return true
+		}
+	}
+	return false
+}
+
+func generateDiagnosticsError(diags diag.Diagnostics) error {
+	var messages []string
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+		message := d.Summary
+		if d.Detail != "" {
+			message += ": " + d.Detail
+		}
+		messages = append(messages, message)
+	}
+	return fmt.Errorf("%s", strings.Join(messages, "; "))
+}
+
+type generator struct {
+	provider  *schema.Provider
+	client    *client
+	types     map[string]bool
+	resources map[string][]*generatedResource // by resource type, in discovery order
+	names     map[string]bool                 // taken `<type>.<name>` addresses
+	log       io.Writer
+}
+
+type generatedResource struct {
+	resourceType string
+	name         string
+	importID     string
+	data         *schema.ResourceData
+}
+
+// generateListAttributes are the attributes of a list endpoint item needed for discovery.
+type generateListAttributes struct {
+	Name *string `json:"name"`
+}
+
+type generateListItem = betterstack.Item[generateListAttributes]
+
+func generateLabel(item generateListItem) string {
+	if item.Attributes.Name != nil && *item.Attributes.Name != "" {
+		return *item.Attributes.Name
+	}
+	return item.ID
+}
+
+// list fetches the items of a list endpoint, every page of it if it's paginated.
+func (g *generator) list(ctx context.Context, path string, paginated bool) ([]generateListItem, error) {
+	if paginated {
+		return betterstack.ListAll[generateListAttributes](ctx, g.client.Client, g.client.TelemetryBaseURL(), path)
+	}
+	var page betterstack.Page[generateListAttributes]
+	if err := g.client.Read(ctx, g.client.TelemetryBaseURL(), path, &page); err != nil {
+		return nil, err
+	}
+	return page.Data, nil
+}
+
+// discover lists the resources of every exported type. Child resources (metrics, charts, alerts,
+// ...) are listed per parent, even if only the children are exported.
+func (g *generator) discover(ctx context.Context) error {
+	wants := func(types ...string) bool {
+		for _, t := range types {
+			if g.types[t] {
+				return true
+			}
+		}
+		return false
+	}
+	export := func(resourceType, path string, paginated bool, importIDPrefix, labelPrefix string) ([]generateListItem, error) {
+		items, err := g.list(ctx, path, paginated)
+		if err != nil {
+			return nil, err
+		}
+		if g.types[resourceType] {
+			for _, item := range items {
+				g.add(ctx, resourceType, importIDPrefix+item.ID, labelPrefix+generateLabel(item))
+			}
+		}
+		return items, nil
+	}
+
+	if wants("logtail_source_group") {
+		if _, err := export("logtail_source_group", "/api/v1/source-groups", true, "", ""); err != nil {
+			return err
+		}
+	}
+	if wants("logtail_source", "logtail_metric") {
+		sources, err := export("logtail_source", "/api/v2/sources", true, "", "")
+		if err != nil {
+			return err
+		}
+		if wants("logtail_metric") {
+			for _, source := range sources {
+				path := fmt.Sprintf("/api/v2/sources/%s/metrics", url.PathEscape(source.ID))
+				if _, err := export("logtail_metric", path, true, source.ID+"/", generateLabel(source)+" "); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if wants("logtail_collector") {
+		if _, err := export("logtail_collector", "/api/v1/collectors", true, "", ""); err != nil {
+			return err
+		}
+	}
+	if wants("logtail_dashboard_group") {
+		if _, err := export("logtail_dashboard_group", "/api/v2/dashboard-groups", true, "", ""); err != nil {
+			return err
+		}
+	}
+	if wants("logtail_dashboard", "logtail_dashboard_section", "logtail_dashboard_chart", "logtail_dashboard_alert") {
+		dashboards, err := export("logtail_dashboard", "/api/v2/dashboards", true, "", "")
+		if err != nil {
+			return err
+		}
+		for _, dashboard := range dashboards {
+			prefix := dashboard.ID + "/"
+			if wants("logtail_dashboard_section") {
+				path := fmt.Sprintf("/api/v2/dashboards/%s/sections", url.PathEscape(dashboard.ID))
+				if _, err := export("logtail_dashboard_section", path, false, prefix, generateLabel(dashboard)+" "); err != nil {
+					return err
+				}
+			}
+			if !wants("logtail_dashboard_chart", "logtail_dashboard_alert") {
+				continue
+			}
+			path := fmt.Sprintf("/api/v2/dashboards/%s/charts", url.PathEscape(dashboard.ID))
+			charts, err := export("logtail_dashboard_chart", path, false, prefix, generateLabel(dashboard)+" ")
+			if err != nil {
+				return err
+			}
+			if !wants("logtail_dashboard_alert") {
+				continue
+			}
+			for _, chart := range charts {
+				path := fmt.Sprintf("/api/v2/dashboards/%s/charts/%s/alerts", url.PathEscape(dashboard.ID), url.PathEscape(chart.ID))
+				if _, err := export("logtail_dashboard_alert", path, false, prefix+chart.ID+"/", generateLabel(chart)+" "); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if wants("logtail_exploration_group") {
+		if _, err := export("logtail_exploration_group", "/api/v2/exploration-groups", true, "", ""); err != nil {
+			return err
+		}
+	}
+	if wants("logtail_exploration", "logtail_exploration_alert") {
+		explorations, err := export("logtail_exploration", "/api/v2/explorations", true, "", "")
+		if err != nil {
+			return err
+		}
+		if wants("logtail_exploration_alert") {
+			for _, exploration := range explorations {
+				path := fmt.Sprintf("/api/v2/explorations/%s/alerts", url.PathEscape(exploration.ID))
+				if _, err := export("logtail_exploration_alert", path, false, exploration.ID+"/", generateLabel(exploration)+" "); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// add imports and reads a resource like `terraform import` does. Resources that fail to read are
+// skipped with a warning, so a single broken resource doesn't prevent the export.
+func (g *generator) add(ctx context.Context, resourceType, importID, label string) {
+	r := g.provider.ResourcesMap[resourceType]
+	d := r.Data(nil)
+	d.SetId(importID)
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		imported, err := r.Importer.StateContext(ctx, d, g.client)
+		if err != nil {
+			fmt.Fprintf(g.log, "Skipping %s %s: %v\n", resourceType, importID, err)
+			return
+		}
+		d = imported[0]
+	}
+	if diags := r.ReadContext(ctx, d, g.client); diags.HasError() {
+		fmt.Fprintf(g.log, "Skipping %s %s: %v\n", resourceType, importID, generateDiagnosticsError(diags))
+		return
+	}
+	if d.Id() == "" {
+		fmt.Fprintf(g.log, "Skipping %s %s: not found\n", resourceType, importID)
+		return
+	}
+
+	res := &generatedResource{
+		resourceType: resourceType,
+		name:         g.uniqueName(resourceType, label),
+		importID:     importID,
+		data:         d,
+	}
+	g.resources[resourceType] = append(g.resources[resourceType], res)
+	fmt.Fprintf(g.log, "Exported %s.%s\n", resourceType, res.name)
+}
+
+var generateNameSeparator = regexp.MustCompile(`[^a-z0-9]+`)
+
+// uniqueName derives a resource name from label, e.g. `production_api` from "Production API".
+func (g *generator) uniqueName(resourceType, label string) string {
+	base := strings.Trim(generateNameSeparator.ReplaceAllString(strings.ToLower(label), "_"), "_")
+	if base == "" || (base[0] >= '0' && base[0] <= '9') {
+		base = strings.TrimPrefix(resourceType, "logtail_") + "_" + base
+		base = strings.TrimSuffix(base, "_")
+	}
+	name := base
+	for i := 2; g.names[resourceType+"."+name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	g.names[resourceType+"."+name] = true
+	return name
+}
+
+// reference returns the resource of resourceType with the given ID, if it was exported.
+func (g *generator) reference(resourceType, id string) *generatedResource {
+	for _, res := range g.resources[resourceType] {
+		if res.data.Id() == id {
+			return res
+		}
+	}
+	return nil
+}
+
+func (g *generator) write(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	imports := hclwrite.NewEmptyFile()
+	count := 0
+	for _, resourceType := range GenerateResourceTypes {
+		resources := g.resources[resourceType]
+		if len(resources) == 0 {
+			continue
+		}
+		file := hclwrite.NewEmptyFile()
+		for i, res := range resources {
+			if i > 0 {
+				file.Body().AppendNewline()
+			}
+			file.Body().AppendBlock(g.renderResource(res))
+
+			block := hclwrite.NewBlock("import", nil)
+			block.Body().SetAttributeTraversal("to", hcl.Traversal{hcl.TraverseRoot{Name: res.resourceType}, hcl.TraverseAttr{Name: res.name}})
+			block.Body().SetAttributeValue("id", cty.StringVal(res.importID))
+			if count > 0 {
+				imports.Body().AppendNewline()
+			}
+			imports.Body().AppendBlock(block)
+			count++
+		}
+		if err := generateWriteFile(filepath.Join(dir, resourceType+".tf"), file); err != nil {
+			return err
+		}
+	}
+	if count == 0 {
+		fmt.Fprintln(g.log, "No resources found")
+		return nil
+	}
+	if err := generateWriteFile(filepath.Join(dir, "imports.tf"), imports); err != nil {
+		return err
+	}
+	fmt.Fprintf(g.log, "Exported %d resources to %s, run `terraform plan` there to review the imports\n", count, dir)
+	return nil
+}
+
+func generateWriteFile(path string, file *hclwrite.File) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(hclwrite.Format(file.Bytes())); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (g *generator) renderResource(res *generatedResource) *hclwrite.Block {
+	s := g.provider.ResourcesMap[res.resourceType].Schema
+	// Only attributes set by the read function are exported.
+	state := res.data.State()
+	values := make(map[string]interface{})
+	for k, attr := range s {
+		_, ok := state.Attributes[k]
+		_, okList := state.Attributes[k+".#"]
+		_, okMap := state.Attributes[k+".%"]
+		if ok || okList || okMap {
+			values[k] = res.data.Get(k)
+		} else if attr.Required {
+			values[k] = res.data.Get(k)
+		}
+	}
+
+	block := hclwrite.NewBlock("resource", []string{res.resourceType, res.name})
+	g.renderBody(block.Body(), s, values, "")
+	return block
+}
+
+// renderBody writes the exported attributes and nested blocks of values, required attributes first.
+func (g *generator) renderBody(body *hclwrite.Body, s map[string]*schema.Schema, values map[string]interface{}, path string) {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if s[keys[i]].Required != s[keys[j]].Required {
+			return s[keys[i]].Required
+		}
+		return keys[i] < keys[j]
+	})
+
+	var sensitive []string
+	for _, k := range keys {
+		if v, ok := values[k]; ok && generateExported(k, s[k]) && s[k].Sensitive && !generateIsZero(v) {
+			sensitive = append(sensitive, path+k)
+		}
+	}
+	if len(sensitive) > 0 {
+		body.AppendUnstructuredTokens(hclwrite.Tokens{{
+			Type:  hclsyntax.TokenComment,
+			Bytes: []byte(fmt.Sprintf("# Sensitive attributes aren't exported, set them manually: %s\n", strings.Join(sensitive, ", "))),
+		}})
+	}
+
+	emitted := make(map[string]bool)
+	for _, k := range keys {
+		attr := s[k]
+		v, ok := values[k]
+		if !ok || !generateExported(k, attr) || attr.Sensitive {
+			continue
+		}
+		if !attr.Required && generateOmitted(attr, v) {
+			continue
+		}
+		conflicts := false
+		for _, c := range attr.ConflictsWith {
+			conflicts = conflicts || emitted[strings.TrimPrefix(c, path)]
+		}
+		if conflicts {
+			continue
+		}
+		emitted[k] = true
+
+		if elem, ok := attr.Elem.(*schema.Resource); ok {
+			for i, item := range generateListValues(v) {
+				m, _ := item.(map[string]interface{})
+				nested := body.AppendNewBlock(k, nil)
+				g.renderBody(nested.Body(), elem.Schema, m, fmt.Sprintf("%s%s.%d.", path, k, i))
+			}
+			continue
+		}
+		if target, ok := generateReferences[k]; ok && path == "" {
+			if ref := g.reference(target, fmt.Sprint(v)); ref != nil {
+				body.SetAttributeTraversal(k, hcl.Traversal{hcl.TraverseRoot{Name: ref.resourceType}, hcl.TraverseAttr{Name: ref.name}, hcl.TraverseAttr{Name: "id"}})
+				continue
+			}
+		}
+		if str, ok := v.(string); ok && strings.HasSuffix(str, "\n") {
+			body.SetAttributeRaw(k, generateHeredoc(str))
+			continue
+		}
+		body.SetAttributeValue(k, generateValue(v))
+	}
+}
+
+// generateExported reports whether an attribute is part of the configuration.
+func generateExported(k string, attr *schema.Schema) bool {
+	return k != "id" && (attr.Required || attr.Optional) && !attr.WriteOnly && attr.Deprecated == ""
+}
+
+// generateOmitted reports whether an optional attribute can be left out, because it has its default
+// (or zero) value.
+func generateOmitted(attr *schema.Schema, v interface{}) bool {
+	if attr.Default != nil {
+		return fmt.Sprint(v) == fmt.Sprint(attr.Default)
+	}
+	return generateIsZero(v)
+}
+
+func generateIsZero(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	case *schema.Set:
+		return v.Len() == 0
+	}
+	return false
+}
+
+func generateListValues(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		return v
+	case *schema.Set:
+		return v.List()
+	}
+	return nil
+}
+
+func generateValue(v interface{}) cty.Value {
+	switch v := v.(type) {
+	case string:
+		return cty.StringVal(v)
+	case int:
+		return cty.NumberIntVal(int64(v))
+	case float64:
+		return cty.NumberFloatVal(v)
+	case bool:
+		return cty.BoolVal(v)
+	case []interface{}, *schema.Set:
+		items := generateListValues(v)
+		if len(items) == 0 {
+			return cty.EmptyTupleVal
+		}
+		values := make([]cty.Value, len(items))
+		for i, item := range items {
+			values[i] = generateValue(item)
+		}
+		return cty.TupleVal(values)
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return cty.EmptyObjectVal
+		}
+		values := make(map[string]cty.Value, len(v))
+		for k, item := range v {
+			values[k] = generateValue(item)
+		}
+		return cty.ObjectVal(values)
+	}
+	return cty.NullVal(cty.DynamicPseudoType)
+}
+
+// generateHeredoc renders a multi-line string, like a VRL program or SQL query, as a heredoc. The
+// content is kept verbatim (no `<<-` indentation stripping), only template sequences are escaped.
+func generateHeredoc(s string) hclwrite.Tokens {
+	marker := "EOT"
+	for strings.Contains("\n"+s, "\n"+marker+"\n") {
+		marker += "_"
+	}
+	escaped := strings.NewReplacer("${", "$${", "%{", "%%{").Replace(s)
+	return hclwrite.Tokens{
+		{Type: hclsyntax.TokenOHeredoc, Bytes: []byte("<<" + marker + "\n")},
+		{Type: hclsyntax.TokenStringLit, Bytes: []byte(escaped)},
+		{Type: hclsyntax.TokenCHeredoc, Bytes: []byte(marker)},
+	}
+}
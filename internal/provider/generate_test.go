@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+func TestGenerate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer foo" {
+			t.Fatal("Not authorized: " + r.Header.Get("Authorization"))
+		}
+		if r.Method != http.MethodGet {
+			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
+		}
+
+		source := `{"id":"1","attributes":{"name":"Web API","platform":"ruby","source_group_id":10,"table_name":"web_api","token":"secret","vrl_transformation_logs":".env = \"${ENV}\"\n"}}`
+		switch r.RequestURI {
+		case "/api/v1/source-groups?page=1":
+			_, _ = w.Write([]byte(`{"data":[{"id":"10","attributes":{"name":"Production"}}],"pagination":{"next":"http://example.com/api/v1/source-groups?page=2"}}`))
+		case "/api/v1/source-groups?page=2":
+			_, _ = w.Write([]byte(`{"data":[{"id":"11","attributes":{"name":"production"}}],"pagination":{"next":null}}`))
+		case "/api/v1/source-groups/10":
+			_, _ = w.Write([]byte(`{"data":{"id":"10","attributes":{"name":"Production","sort_index":1}}}`))
+		case "/api/v1/source-groups/11":
+			_, _ = w.Write([]byte(`{"data":{"id":"11","attributes":{"name":"production"}}}`))
+		case "/api/v2/sources?page=1":
+			_, _ = w.Write([]byte(`{"data":[` + source + `],"pagination":{"next":null}}`))
+		case "/api/v2/sources/1":
+			_, _ = w.Write([]byte(`{"data":` + source + `}`))
+		case "/api/v2/sources/1/metrics?page=1":
+			_, _ = w.Write([]byte(`{"data":[{"id":"5","attributes":{"name":"duration","sql_expression":"getJSON(raw, 'duration')","aggregations":["avg"]}}],"pagination":{"next":null}}`))
+		default:
+			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("LOGTAIL_API_TOKEN", "foo")
+	dir := t.TempDir()
+	var log bytes.Buffer
+	err := Generate(context.Background(), GenerateOptions{
+		OutputDir:     dir,
+		ResourceTypes: []string{"logtail_source_group", "logtail_source", "logtail_metric"},
+		Log:           &log,
+	}, WithURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(name string) string {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, diags := hclparse.NewParser().ParseHCL(b, name); diags.HasErrors() {
+			t.Fatalf("%s is not valid HCL: %v\n%s", name, diags, b)
+		}
+		// Ignore the alignment of attributes.
+		return regexp.MustCompile(` +`).ReplaceAllString(string(b), " ")
+	}
+
+	groups := read("logtail_source_group.tf")
+	for _, want := range []string{`resource "logtail_source_group" "production" {`, `resource "logtail_source_group" "production_2" {`, `sort_index = 1`} {
+		if !strings.Contains(groups, want) {
+			t.Errorf("Expected %q in logtail_source_group.tf:\n%s", want, groups)
+		}
+	}
+
+	sources := read("logtail_source.tf")
+	for _, want := range []string{
+		`resource "logtail_source" "web_api" {`,
+		`source_group_id = logtail_source_group.production.id`,
+		"vrl_transformation_logs = <<EOT\n.env = \"$${ENV}\"\nEOT",
+	} {
+		if !strings.Contains(sources, want) {
+			t.Errorf("Expected %q in logtail_source.tf:\n%s", want, sources)
+		}
+	}
+	if strings.Contains(sources, "secret") || strings.Contains(sources, "table_name") {
+		t.Errorf("Expected computed attributes not to be exported:\n%s", sources)
+	}
+
+	metrics := read("logtail_metric.tf")
+	for _, want := range []string{`resource "logtail_metric" "web_api_duration" {`, `source_id = logtail_source.web_api.id`, `aggregations = ["avg"]`} {
+		if !strings.Contains(metrics, want) {
+			t.Errorf("Expected %q in logtail_metric.tf:\n%s", want, metrics)
+		}
+	}
+
+	imports := read("imports.tf")
+	for _, want := range []string{"to = logtail_source_group.production_2\n id = \"11\"", "to = logtail_metric.web_api_duration\n id = \"1/5\""} {
+		if !strings.Contains(imports, want) {
+			t.Errorf("Expected %q in imports.tf:\n%s", want, imports)
+		}
+	}
+
+	// Existing files are never overwritten.
+	err = Generate(context.Background(), GenerateOptions{OutputDir: dir, ResourceTypes: []string{"logtail_source_group"}}, WithURL(server.URL))
+	if err == nil || !strings.Contains(err.Error(), "exists") {
+		t.Errorf("Expected an error about existing files, got %v", err)
+	}
+
+	if err := Generate(context.Background(), GenerateOptions{OutputDir: dir, ResourceTypes: []string{"logtail_connection"}}, WithURL(server.URL)); err == nil {
+		t.Error("Expected an error for an unsupported resource type")
+	}
+}
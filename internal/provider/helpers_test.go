@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/betterstackhq/terraform-provider-logtail/internal/fakeapi"
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// configureTestProvider returns the provider configured with args against the fake API.
+func configureTestProvider(t *testing.T, api *fakeapi.Server, args map[string]interface{}) *schema.Provider {
+	t.Helper()
+	p := New(WithURL(api.URL))
+	args["api_token"] = fakeapi.Token
+	// Like the gRPC server, pass the raw config along for GetRawConfig.
+	block := (&schema.Resource{Schema: p.Schema}).CoreConfigSchema()
+	raw := rawTestConfig(t, block.ImpliedType(), args)
+	config := terraform.NewResourceConfigShimmed(raw, block)
+	config.CtyValue = raw
+	if diags := p.Configure(context.Background(), config); diags.HasError() {
+		t.Fatal(diags)
+	}
+	return p
+}
+
+// rawTestConfig returns config as the raw config Terraform sends, with every attribute of typ,
+// null unless configured. GetRawConfig and the write-only attributes read it.
+func rawTestConfig(t *testing.T, typ cty.Type, config map[string]interface{}) cty.Value {
+	t.Helper()
+	b, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var values map[string]interface{}
+	_ = json.Unmarshal(b, &values)
+	for k := range typ.AttributeTypes() {
+		if _, ok := values[k]; !ok {
+			values[k] = nil
+		}
+	}
+	b, _ = json.Marshal(values)
+	v, err := ctyjson.Unmarshal(b, typ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+// applyTestResource plans and applies the configuration of a new resource like Terraform does,
+// with the raw config that write-only attributes and stringFromResourceData are read from.
+func applyTestResource(t *testing.T, p *schema.Provider, name string, config map[string]interface{}) (*terraform.InstanceState, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+	r := p.ResourcesMap[name]
+	diff, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(config), p.Meta())
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff.RawConfig = rawTestConfig(t, r.CoreConfigSchema().ImpliedType(), config)
+	return r.Apply(ctx, nil, diff, p.Meta())
+}
+
+// newTestServer returns a test API server answering requests with handler. It has no platform
+// catalog, so the platforms known to the provider are accepted.
+func newTestServer(handler http.HandlerFunc) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/api/v2/platforms" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		handler(w, r)
+	}))
+}
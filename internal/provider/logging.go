@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"context"
+	"os"
+
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// logSensitiveEnv disables the redaction of request and response bodies in the logs.
+const logSensitiveEnv = "TF_PROVIDER_LOGTAIL_LOG_INSECURE"
+
+// tflogLogger sends the logs of the API client to Terraform, in the api, retry and ratelimit
+// subsystems. Their level can be set with TF_LOG_PROVIDER_LOGTAIL_API etc., and they carry the
+// fields Terraform sets on the request context, e.g. tf_req_id and tf_resource_type.
+type tflogLogger struct{}
+
+var _ betterstack.Logger = tflogLogger{}
+
+func (tflogLogger) Log(ctx context.Context, subsystem string, level betterstack.LogLevel, msg string, fields map[string]interface{}) {
+	ctx = tflog.NewSubsystem(ctx, subsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_LOGTAIL", subsystem), tflog.WithRootFields())
+	switch level {
+	case betterstack.LogWarn:
+		tflog.SubsystemWarn(ctx, subsystem, msg, fields)
+	case betterstack.LogInfo:
+		tflog.SubsystemInfo(ctx, subsystem, msg, fields)
+	default:
+		tflog.SubsystemDebug(ctx, subsystem, msg, fields)
+	}
+}
+
+func logSensitive() bool {
+	return os.Getenv(logSensitiveEnv) == "1"
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestTflogLogger(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_LOGTAIL_RATELIMIT", "WARN")
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	ctx = tflog.SetField(ctx, "tf_req_id", "req-1")
+
+	logger := tflogLogger{}
+	logger.Log(ctx, betterstack.LogSubsystemAPI, betterstack.LogDebug, "Sending API request", map[string]interface{}{"request_id": "abc"})
+	logger.Log(ctx, betterstack.LogSubsystemRateLimit, betterstack.LogDebug, "Waited for the rate limiter", map[string]interface{}{"request_id": "abc"})
+	logger.Log(ctx, betterstack.LogSubsystemRetry, betterstack.LogWarn, "Retrying API request", map[string]interface{}{"request_id": "abc"})
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2 (ratelimit is set to WARN): %v", len(entries), entries)
+	}
+	for i, want := range []struct{ module, level, message string }{
+		{"provider.api", "debug", "Sending API request"},
+		{"provider.retry", "warn", "Retrying API request"},
+	} {
+		e := entries[i]
+		if e["@module"] != want.module || e["@level"] != want.level || e["@message"] != want.message {
+			t.Errorf("entry %d: got %v, want %v", i, e, want)
+		}
+		if e["request_id"] != "abc" || e["tf_req_id"] != "req-1" {
+			t.Errorf("entry %d: got fields %v, want request_id and tf_req_id", i, e)
+		}
+	}
+}
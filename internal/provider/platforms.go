@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The platforms of sources and errors applications are validated at plan time against the
+// platform catalog of the API, so new integrations can be used without a provider release.
+// platformTypes and errorsPlatformTypes, the platforms known when the provider was released, are
+// only the fallback when the catalog can't be read, e.g. offline.
+
+// The catalog kinds of the platforms of sources and errors applications.
+var (
+	sourcePlatformKinds = []string{"logs", "metrics"}
+	errorsPlatformKinds = []string{"errors"}
+)
+
+// platformCatalog caches the platform catalog for the lifetime of the provider. Failed reads
+// aren't cached, the next validation reads the catalog again.
+type platformCatalog struct {
+	mu        sync.Mutex
+	read      bool
+	platforms []betterstack.Platform
+}
+
+// platforms returns the platform catalog, read from the API until a read succeeds.
+func (c *client) platforms(ctx context.Context) ([]betterstack.Platform, error) {
+	c.catalog.mu.Lock()
+	defer c.catalog.mu.Unlock()
+	if c.catalog.read {
+		return c.catalog.platforms, nil
+	}
+	var platforms []betterstack.Platform
+	for e, err := range c.Platforms().List(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		platforms = append(platforms, e.Attributes)
+	}
+	c.catalog.platforms, c.catalog.read = platforms, true
+	return platforms, nil
+}
+
+// platformNames returns the names of the platforms of the given kinds.
+func platformNames(platforms []betterstack.Platform, kinds []string) []string {
+	var names []string
+	for _, p := range platforms {
+		if p.Name != nil && p.Kind != nil && slices.Contains(kinds, *p.Kind) {
+			names = append(names, *p.Name)
+		}
+	}
+	return names
+}
+
+// validatePlatform checks a new platform against the platform catalog. If the catalog can't be
+// read or has no platforms of the kinds, the platforms in known are accepted instead.
+func validatePlatform(known []string, kinds []string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		if !diff.HasChange("platform") || !diff.NewValueKnown("platform") {
+			return nil
+		}
+		platform := diff.Get("platform").(string)
+
+		var names []string
+		var err error
+		if c, ok := meta.(*client); ok {
+			var platforms []betterstack.Platform
+			if platforms, err = c.platforms(ctx); err == nil {
+				names = platformNames(platforms, kinds)
+			}
+		}
+		if len(names) > 0 {
+			if !slices.Contains(names, platform) {
+				return fmt.Errorf("invalid platform %q, expected one of %v, see the logtail_platforms data source", platform, names)
+			}
+			return nil
+		}
+
+		if slices.Contains(known, platform) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid platform %q, expected one of %v (the platform catalog couldn't be read: %w)", platform, known, err)
+		}
+		return fmt.Errorf("invalid platform %q, expected one of %v", platform, known)
+	}
+}
+
+var platformsElemSchema = map[string]*schema.Schema{
+	"name": {
+		Description: "The name of the platform, the `platform` of a source or errors application.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	},
+	"kind": {
+		Description: "The kind of the platform: `logs` or `metrics` for sources, `errors` for errors applications.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	},
+	"display_name": {
+		Description: "The name of the platform in Better Stack.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	},
+	"docs_url": {
+		Description: "The documentation of the integration.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	},
+}
+
+func newPlatformsDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: platformsLookup,
+		Description: "This Data Source lists the platform catalog of Better Stack, the valid `platform` values of sources and errors applications.",
+		Schema: map[string]*schema.Schema{
+			"kind": {
+				Description:  "Only return platforms of this kind: `logs`, `metrics` or `errors`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"logs", "metrics", "errors"}, false),
+			},
+			"platforms": {
+				Description: "The list of platforms, in the order returned by the API.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Resource{Schema: platformsElemSchema},
+			},
+		},
+	}
+}
+
+func platformsLookup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	all, err := meta.(*client).platforms(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	kind := d.Get("kind").(string)
+
+	platforms := make([]interface{}, 0)
+	for _, p := range all {
+		if kind != "" && (p.Kind == nil || *p.Kind != kind) {
+			continue
+		}
+		m := make(map[string]interface{})
+		for k, v := range map[string]*string{"name": p.Name, "kind": p.Kind, "display_name": p.DisplayName, "docs_url": p.DocsURL} {
+			if v != nil {
+				m[k] = *v
+			}
+		}
+		platforms = append(platforms, m)
+	}
+
+	d.SetId("platforms")
+	return diag.FromErr(d.Set("platforms", platforms))
+}
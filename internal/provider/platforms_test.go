@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/betterstackhq/terraform-provider-logtail/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestValidatePlatform(t *testing.T) {
+	tests := []struct {
+		name      string
+		catalog   bool
+		resource  string
+		platform  string
+		wantErr   string
+		wantReads int
+	}{
+		{name: "catalog source platform", catalog: true, resource: "logtail_source", platform: "new_integration", wantReads: 1},
+		{name: "catalog metrics platform", catalog: true, resource: "logtail_source", platform: "new_exporter", wantReads: 1},
+		{name: "known platform missing from the catalog", catalog: true, resource: "logtail_source", platform: "kubernetes", wantErr: `invalid platform "kubernetes", expected one of [new_integration new_exporter]`, wantReads: 1},
+		{name: "errors platform for a source", catalog: true, resource: "logtail_source", platform: "new_sdk", wantErr: `invalid platform "new_sdk", expected one of [new_integration new_exporter]`, wantReads: 1},
+		{name: "catalog errors platform", catalog: true, resource: "logtail_errors_application", platform: "new_sdk", wantReads: 1},
+		{name: "unknown platform", catalog: true, resource: "logtail_source", platform: "nope", wantErr: "see the logtail_platforms data source", wantReads: 1},
+		{name: "empty catalog", resource: "logtail_source", platform: "new_integration", wantErr: `invalid platform "new_integration", expected one of [apache2`, wantReads: 1},
+		{name: "known platform with empty catalog", resource: "logtail_source", platform: "ubuntu", wantReads: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := fakeapi.New(t)
+			if tt.catalog {
+				api.Seed("/api/v2/platforms", map[string]interface{}{"name": "new_integration", "kind": "logs", "display_name": "New integration", "docs_url": "https://betterstack.com/docs/logs/new-integration/"})
+				api.Seed("/api/v2/platforms", map[string]interface{}{"name": "new_exporter", "kind": "metrics"})
+				api.Seed("/api/v2/platforms", map[string]interface{}{"name": "new_sdk", "kind": "errors"})
+			}
+			p := configureTestProvider(t, api, map[string]interface{}{})
+			r := p.ResourcesMap[tt.resource]
+			ctx := context.Background()
+
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{"name": "Test", "platform": tt.platform})
+			// Validating twice reads the catalog once.
+			for i := 0; i < 2; i++ {
+				_, err := r.Diff(ctx, nil, config, p.Meta())
+				if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+			}
+			reads := 0
+			for _, req := range api.Requests() {
+				if strings.HasPrefix(req, "GET /api/v2/platforms") {
+					reads++
+				}
+			}
+			if reads != tt.wantReads {
+				t.Errorf("got %d catalog reads, want %d", reads, tt.wantReads)
+			}
+		})
+	}
+}
+
+func TestValidatePlatformRereadsCatalogAfterFailures(t *testing.T) {
+	api := fakeapi.New(t)
+	api.Seed("/api/v2/platforms", map[string]interface{}{"name": "new_integration", "kind": "logs"})
+	p := configureTestProvider(t, api, map[string]interface{}{})
+	r := p.ResourcesMap["logtail_source"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"name": "Test", "platform": "new_integration"})
+
+	// A cancelled plan fails to read the catalog, the known platforms are the fallback.
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := r.Diff(cancelled, nil, config, p.Meta()); err == nil || !strings.Contains(err.Error(), "the platform catalog couldn't be read") {
+		t.Fatalf("got error %v, want the catalog read to fail", err)
+	}
+	if _, err := r.Diff(context.Background(), nil, config, p.Meta()); err != nil {
+		t.Errorf("got error %v, want the catalog read again", err)
+	}
+}
+
+func TestDataSourcePlatforms(t *testing.T) {
+	api := fakeapi.New(t)
+	api.Seed("/api/v2/platforms", map[string]interface{}{"name": "new_integration", "kind": "logs", "display_name": "New integration", "docs_url": "https://betterstack.com/docs/logs/new-integration/"})
+	api.Seed("/api/v2/platforms", map[string]interface{}{"name": "new_sdk", "kind": "errors"})
+	p := configureTestProvider(t, api, map[string]interface{}{})
+	r := p.DataSourcesMap["logtail_platforms"]
+
+	d := r.TestResourceData()
+	if err := d.Set("kind", "logs"); err != nil {
+		t.Fatal(err)
+	}
+	if diags := r.ReadContext(context.Background(), d, p.Meta()); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got := d.Get("platforms.#"); got != 1 {
+		t.Fatalf("got %v platforms, want 1", got)
+	}
+	for k, want := range map[string]string{"name": "new_integration", "kind": "logs", "display_name": "New integration", "docs_url": "https://betterstack.com/docs/logs/new-integration/"} {
+		if got := d.Get("platforms.0." + k); got != want {
+			t.Errorf("got %s %q, want %q", k, got, want)
+		}
+	}
+}
@@ -1,11 +1,15 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"sync/atomic"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -62,3 +66,138 @@ func TestProviderInit(t *testing.T) {
 		t.Fatalf("HTTP server didn't receive any requests")
 	}
 }
+
+// TestProviderURLs verifies telemetry_url and errors_url route the requests of telemetry and errors
+// resources to two distinct servers.
+func TestProviderURLs(t *testing.T) {
+	newServer := func(name, prefix string) (*httptest.Server, *int32) {
+		var requests int32
+		var data atomic.Value
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Log(name + " received " + r.Method + " " + r.RequestURI)
+			atomic.AddInt32(&requests, 1)
+
+			id := "1"
+			switch {
+			case r.Method == http.MethodPost && r.RequestURI == prefix:
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				data.Store(body)
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(fmt.Sprintf(`{"data":{"id":%q,"attributes":%s}}`, id, body)))
+			case r.Method == http.MethodGet && r.RequestURI == prefix+"/"+id:
+				_, _ = w.Write([]byte(fmt.Sprintf(`{"data":{"id":%q,"attributes":%s}}`, id, data.Load().([]byte))))
+			case r.Method == http.MethodDelete && r.RequestURI == prefix+"/"+id:
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				t.Errorf("Unexpected %s %s on the %s server", r.Method, r.RequestURI, name)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		return server, &requests
+	}
+	telemetryServer, telemetryRequests := newServer("telemetry", "/api/v1/source-groups")
+	defer telemetryServer.Close()
+	errorsServer, errorsRequests := newServer("errors", "/api/v1/application-groups")
+	defer errorsServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"logtail": func() (*schema.Provider, error) {
+				return New(), nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				provider "logtail" {
+					api_token     = "foo"
+					telemetry_url = %q
+					errors_url    = "%s/"
+				}
+
+				resource "logtail_source_group" "this" {
+					name = "Test Source Group"
+				}
+
+				resource "logtail_errors_application_group" "this" {
+					name = "Test Application Group"
+				}
+				`, telemetryServer.URL, errorsServer.URL),
+			},
+		},
+	})
+
+	if atomic.LoadInt32(telemetryRequests) == 0 || atomic.LoadInt32(errorsRequests) == 0 {
+		t.Fatalf("Expected requests on both servers, got %d telemetry and %d errors requests", atomic.LoadInt32(telemetryRequests), atomic.LoadInt32(errorsRequests))
+	}
+}
+
+func TestProviderInitMuxServer(t *testing.T) {
+	var success int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Log("Received " + r.Method + " " + r.RequestURI)
+
+		if r.Header.Get("Authorization") != "Bearer foo" {
+			t.Fatal("Not authorized: " + r.Header.Get("Authorization"))
+		}
+
+		atomic.StoreInt32(&success, 1)
+		_, _ = w.Write([]byte(`{"data":[{"id":"1","attributes":{"name":"Test source","platform":"ubuntu","token":"token123","table_name":"abc","ingesting_paused":false}}],"pagination":{"next":null}}`))
+	}))
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"logtail": func() (tfprotov6.ProviderServer, error) {
+				muxServer, err := NewMuxServer(context.Background(), WithURL(server.URL))
+				if err != nil {
+					return nil, err
+				}
+				return muxServer(), nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "logtail" {
+					api_token = "foo"
+				}
+				data "logtail_source" "this" {
+					table_name = "abc"
+				}
+				`,
+				Check: resource.TestCheckResourceAttr("data.logtail_source.this", "platform", "ubuntu"),
+			},
+		},
+	})
+
+	if atomic.LoadInt32(&success) != int32(1) {
+		t.Fatalf("HTTP server didn't receive any requests")
+	}
+}
+
+func TestMuxServer(t *testing.T) {
+	ctx := context.Background()
+	muxServer, err := NewMuxServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The mux server rejects a provider schema that differs between the SDKv2 and framework providers.
+	resp, err := muxServer().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Errorf("%s: %s", d.Summary, d.Detail)
+	}
+	if _, ok := resp.ResourceSchemas["logtail_source"]; !ok {
+		t.Error("logtail_source resource missing from the mux server")
+	}
+}
@@ -1,97 +1,29 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"io"
-	"log"
+	"errors"
+	"fmt"
 	"net/http"
 
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceCreate(ctx context.Context, meta interface{}, url string, in, out interface{}) diag.Diagnostics {
-	reqBody, err := json.Marshal(&in)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	log.Printf("POST %s: %s", url, string(reqBody))
-	res, err := meta.(*client).Post(ctx, url, bytes.NewReader(reqBody))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer func() {
-		// Keep-Alive.
-		_, _ = io.Copy(io.Discard, res.Body)
-		_ = res.Body.Close()
-	}()
-	body, err := io.ReadAll(res.Body)
-	if res.StatusCode != http.StatusCreated {
-		return diag.Errorf("POST %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	}
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	log.Printf("POST %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	if err := json.Unmarshal(body, &out); err != nil {
-		return diag.FromErr(err)
-	}
-	return nil
+	return resourceCreateWithBaseURL(ctx, meta, meta.(*client).TelemetryBaseURL(), url, in, out)
 }
 
 func resourceCreateWithBaseURL(ctx context.Context, meta interface{}, baseURL, path string, in, out interface{}) diag.Diagnostics {
-	reqBody, err := json.Marshal(&in)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	log.Printf("POST %s%s: %s", baseURL, path, string(reqBody))
-	res, err := meta.(*client).PostWithBaseURL(ctx, baseURL, path, bytes.NewReader(reqBody))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer func() {
-		// Keep-Alive.
-		_, _ = io.Copy(io.Discard, res.Body)
-		_ = res.Body.Close()
-	}()
-	body, err := io.ReadAll(res.Body)
-	if res.StatusCode != http.StatusCreated {
-		return diag.Errorf("POST %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	}
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	log.Printf("POST %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	if err := json.Unmarshal(body, &out); err != nil {
-		return diag.FromErr(err)
-	}
-	return nil
+	return writeDiagnostics(meta.(*client).Create(ctx, baseURL, path, in, out))
 }
 
 func resourceReadWithBaseURL(ctx context.Context, meta interface{}, baseURL, path string, out interface{}) (derr diag.Diagnostics, ok bool) {
-	log.Printf("GET %s%s", baseURL, path)
-	res, err := meta.(*client).GetWithBaseURL(ctx, baseURL, path)
-	if err != nil {
-		return diag.FromErr(err), false
-	}
-	defer func() {
-		// Keep-Alive.
-		_, _ = io.Copy(io.Discard, res.Body)
-		_ = res.Body.Close()
-	}()
-	if res.StatusCode == http.StatusNotFound {
+	err := meta.(*client).Read(ctx, baseURL, path, out)
+	if betterstack.IsNotFound(err) {
 		return nil, false
 	}
-	body, err := io.ReadAll(res.Body)
-	if res.StatusCode != http.StatusOK {
-		return diag.Errorf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body)), false
-	}
-	if err != nil {
-		return diag.FromErr(err), false
-	}
-	log.Printf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	err = json.Unmarshal(body, &out)
 	if err != nil {
 		return diag.FromErr(err), false
 	}
@@ -99,85 +31,58 @@ func resourceReadWithBaseURL(ctx context.Context, meta interface{}, baseURL, pat
 }
 
 func resourceUpdate(ctx context.Context, meta interface{}, url string, req interface{}) diag.Diagnostics {
-	reqBody, err := json.Marshal(&req)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	log.Printf("PATCH %s: %s", url, string(reqBody))
-	res, err := meta.(*client).Patch(ctx, url, bytes.NewReader(reqBody))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer func() {
-		// Keep-Alive.
-		_, _ = io.Copy(io.Discard, res.Body)
-		_ = res.Body.Close()
-	}()
-	body, _ := io.ReadAll(res.Body)
-	if res.StatusCode != http.StatusOK {
-		return diag.Errorf("PATCH %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	}
-	log.Printf("PATCH %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	return nil
+	return resourceUpdateWithBaseURL(ctx, meta, meta.(*client).TelemetryBaseURL(), url, req)
 }
 
 func resourceUpdateWithBaseURL(ctx context.Context, meta interface{}, baseURL, path string, req interface{}) diag.Diagnostics {
-	reqBody, err := json.Marshal(&req)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	log.Printf("PATCH %s%s: %s", baseURL, path, string(reqBody))
-	res, err := meta.(*client).PatchWithBaseURL(ctx, baseURL, path, bytes.NewReader(reqBody))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer func() {
-		// Keep-Alive.
-		_, _ = io.Copy(io.Discard, res.Body)
-		_ = res.Body.Close()
-	}()
-	body, _ := io.ReadAll(res.Body)
-	if res.StatusCode != http.StatusOK {
-		return diag.Errorf("PATCH %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	}
-	log.Printf("PATCH %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	return nil
+	return writeDiagnostics(meta.(*client).Update(ctx, baseURL, path, req))
 }
 
 func resourceDelete(ctx context.Context, meta interface{}, url string) diag.Diagnostics {
-	log.Printf("DELETE %s", url)
-	res, err := meta.(*client).Delete(ctx, url)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer func() {
-		// Keep-Alive.
-		_, _ = io.Copy(io.Discard, res.Body)
-		_ = res.Body.Close()
-	}()
-	body, _ := io.ReadAll(res.Body)
-	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusNotFound {
-		return diag.Errorf("DELETE %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	}
-	log.Printf("DELETE %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	return nil
+	return resourceDeleteWithBaseURL(ctx, meta, meta.(*client).TelemetryBaseURL(), url)
 }
 
 func resourceDeleteWithBaseURL(ctx context.Context, meta interface{}, baseURL, path string) diag.Diagnostics {
-	log.Printf("DELETE %s%s", baseURL, path)
-	res, err := meta.(*client).DeleteWithBaseURL(ctx, baseURL, path)
-	if err != nil {
-		return diag.FromErr(err)
+	return writeDiagnostics(meta.(*client).Remove(ctx, baseURL, path))
+}
+
+// writeDiagnostics maps the validation errors of a failed POST or PATCH to the attributes they
+// are about, see apiErrorDiagnostics, and explains requests refused in read_only mode.
+func writeDiagnostics(err error) diag.Diagnostics {
+	var apiErr *betterstack.APIError
+	if errors.As(err, &apiErr) && (apiErr.Method == http.MethodPost || apiErr.Method == http.MethodPatch) {
+		return apiErrorDiagnostics(apiErr.Method, apiErr.URL, apiErr.StatusCode, apiErr.Body)
+	}
+	var readOnlyErr *betterstack.ReadOnlyError
+	if errors.As(err, &readOnlyErr) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "The provider is read-only",
+			Detail:   fmt.Sprintf("read_only is set in the provider configuration, so %s %s wasn't sent. Remove read_only to apply changes.", readOnlyErr.Method, readOnlyErr.URL),
+		}}
+	}
+	return diag.FromErr(err)
+}
+
+// withResourceContext names the resource in the context of its create, read, update and delete
+// functions, so the audit log records which resource each request was sent for.
+func withResourceContext(name string, r *schema.Resource) *schema.Resource {
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return f(betterstack.WithResource(ctx, name, d.Id()), d, meta)
+		}
+	}
+	if r.CreateContext != nil {
+		r.CreateContext = wrap(r.CreateContext)
+	}
+	if r.ReadContext != nil {
+		r.ReadContext = wrap(r.ReadContext)
+	}
+	if r.UpdateContext != nil {
+		r.UpdateContext = wrap(r.UpdateContext)
 	}
-	defer func() {
-		// Keep-Alive.
-		_, _ = io.Copy(io.Discard, res.Body)
-		_ = res.Body.Close()
-	}()
-	body, _ := io.ReadAll(res.Body)
-	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusNotFound {
-		return diag.Errorf("DELETE %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
+	if r.DeleteContext != nil {
+		r.DeleteContext = wrap(r.DeleteContext)
 	}
-	log.Printf("DELETE %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	return nil
+	return r
 }
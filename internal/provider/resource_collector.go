@@ -12,6 +12,8 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -211,6 +213,7 @@ var collectorSchema = map[string]*schema.Schema{
 		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 			return normalizeVRL(old) == normalizeVRL(new)
 		},
+		ValidateDiagFunc: validateVRL,
 	},
 	"configuration": {
 		Description: "Collector-level configuration including active components, sampling rates, batching, and VRL transformations. These settings run on the collector host inside your infrastructure.",
@@ -266,6 +269,7 @@ var collectorSchema = map[string]*schema.Schema{
 					DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 						return normalizeVRL(old) == normalizeVRL(new)
 					},
+					ValidateDiagFunc: validateVRL,
 				},
 				"merge_logs": {
 					Description: "Whether to merge multi-line logs (e.g. stack traces) into single log entries on the collector host before transmission. Matches the Merge logs tab in the collector's Transform data UI.",
@@ -355,7 +359,8 @@ var collectorSchema = map[string]*schema.Schema{
 				"name":                      {Description: "Bucket name derived from `endpoint`. Deprecated - do not set this attribute.", Deprecated: "Do not set the bucket name - it is always derived from `endpoint`. This attribute will be removed in a future release.", Type: schema.TypeString, Optional: true, Computed: true},
 				"endpoint":                  {Description: "Bucket endpoint including the bucket name, e.g. `https://s3.us-east-1.amazonaws.com/my-bucket` or `https://my-bucket.s3.us-east-1.amazonaws.com`.", Type: schema.TypeString, Required: true, ValidateFunc: validation.StringIsNotEmpty},
 				"access_key_id":             {Description: "Access key ID for the bucket.", Type: schema.TypeString, Required: true, ValidateFunc: validation.StringIsNotEmpty},
-				"secret_access_key":         {Description: "Secret access key for the bucket.", Type: schema.TypeString, Required: true, Sensitive: true, ValidateFunc: validation.StringIsNotEmpty},
+				"secret_access_key":         {Description: "Secret access key for the bucket. Exactly one of `secret_access_key` or `secret_access_key_wo` must be set.", Type: schema.TypeString, Optional: true, Sensitive: true, ValidateFunc: validation.StringIsNotEmpty, ExactlyOneOf: []string{"custom_bucket.0.secret_access_key", "custom_bucket.0.secret_access_key_wo"}},
+				"secret_access_key_wo":      {Description: "Secret access key for the bucket as a write-only attribute (requires Terraform 1.11 or later), it is sent to the API when the collector is created but never stored in the state. As `custom_bucket` can't be changed after creation, there is no version attribute to rotate it.", Type: schema.TypeString, Optional: true, Sensitive: true, WriteOnly: true, ValidateFunc: validation.StringIsNotEmpty, ExactlyOneOf: []string{"custom_bucket.0.secret_access_key", "custom_bucket.0.secret_access_key_wo"}},
 				"keep_data_after_retention": {Description: "Whether to keep data in the bucket after the retention period.", Type: schema.TypeBool, Optional: true, Default: false},
 			},
 		},
@@ -372,118 +377,34 @@ var collectorSchema = map[string]*schema.Schema{
 				"host":         {Description: "The database host.", Type: schema.TypeString, Required: true},
 				"port":         {Description: "The database port.", Type: schema.TypeInt, Required: true},
 				"username":     {Description: "The database username.", Type: schema.TypeString, Optional: true},
-				"password":     {Description: "The database password.", Type: schema.TypeString, Optional: true, Sensitive: true},
-				"ssl_mode":     {Description: "SSL mode for PostgreSQL connections. Valid values: `disable`, `require`, `verify-ca`.", Type: schema.TypeString, Optional: true, ValidateFunc: validation.StringInSlice([]string{"disable", "require", "verify-ca"}, false)},
-				"tls":          {Description: "TLS mode for MySQL connections. Valid values: `false`, `true`, `skip-verify`, `preferred`.", Type: schema.TypeString, Optional: true, ValidateFunc: validation.StringInSlice([]string{"false", "true", "skip-verify", "preferred"}, false)},
+				"password":     {Description: "The database password. Conflicts with `password_wo`.", Type: schema.TypeString, Optional: true, Sensitive: true},
+				"password_wo": {
+					Description: "Write-only alternative to `password`, never stored in the Terraform plan or state. Requires Terraform 1.11 or later and `password_wo_version`. Increment `password_wo_version` to send a changed password.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					WriteOnly:   true,
+				},
+				"password_wo_version": {Description: "Version of `password_wo`. Change it to update the password of this database connection.", Type: schema.TypeInt, Optional: true},
+				"ssl_mode":            {Description: "SSL mode for PostgreSQL connections. Valid values: `disable`, `require`, `verify-ca`.", Type: schema.TypeString, Optional: true, ValidateFunc: validation.StringInSlice([]string{"disable", "require", "verify-ca"}, false)},
+				"tls":                 {Description: "TLS mode for MySQL connections. Valid values: `false`, `true`, `skip-verify`, `preferred`.", Type: schema.TypeString, Optional: true, ValidateFunc: validation.StringInSlice([]string{"false", "true", "skip-verify", "preferred"}, false)},
 			},
 		},
 	},
 }
 
-// Go structs for API serialization
-
-// collectorComponents maps the flat API component names to their JSON keys.
-type collectorComponents struct {
-	LogsHost              *bool `json:"logs_host,omitempty"`
-	LogsDocker            *bool `json:"logs_docker,omitempty"`
-	LogsKubernetes        *bool `json:"logs_kubernetes,omitempty"`
-	LogsCollectorInternal *bool `json:"logs_collector_internals,omitempty"`
-	MetricsDatabases      *bool `json:"metrics_databases,omitempty"`
-	MetricsNginx          *bool `json:"metrics_nginx,omitempty"`
-	MetricsApache         *bool `json:"metrics_apache,omitempty"`
-	MetricsTraefik        *bool `json:"metrics_traefik,omitempty"`
-	EbpfMetrics           *bool `json:"ebpf_metrics,omitempty"`
-	EbpfTracingBasic      *bool `json:"ebpf_tracing_basic,omitempty"`
-	EbpfTracingFull       *bool `json:"ebpf_tracing_full,omitempty"`
-	TracesOpentelemetry   *bool `json:"traces_opentelemetry,omitempty"`
-	EbpfRedMetrics        *bool `json:"ebpf_red_metrics,omitempty"`
-}
-
-type collectorEntityOption struct {
-	LogSampling  *int  `json:"log_sampling,omitempty"`
-	IngestTraces *bool `json:"ingest_traces,omitempty"`
-}
-
-type collectorConfiguration struct {
-	LogsSampleRate       *int                             `json:"logs_sample_rate,omitempty"`
-	TracesSampleRate     *int                             `json:"traces_sample_rate,omitempty"`
-	Components           *collectorComponents             `json:"components,omitempty"`
-	VRLTransformation    *string                          `json:"vrl_transformation,omitempty"`
-	MergeLogs            *bool                            `json:"merge_logs,omitempty"`
-	MergeLogsConfig      *string                          `json:"merge_logs_config,omitempty"`
-	DiskBatchSizeMB      *int                             `json:"disk_batch_size_mb,omitempty"`
-	MemoryBatchSizeMB    *int                             `json:"memory_batch_size_mb,omitempty"`
-	BufferMaxEvents      *int                             `json:"buffer_max_events,omitempty"`
-	WhenFull             *string                          `json:"when_full,omitempty"`
-	LogLineLengthLimitKB *int                             `json:"log_line_length_limit_kb,omitempty"`
-	ServicesOptions      map[string]collectorEntityOption `json:"services_options,omitempty"`
-	NamespacesOptions    map[string]collectorEntityOption `json:"namespaces_options,omitempty"`
-}
-
-type collectorCustomBucket struct {
-	Name                   *string `json:"name,omitempty"`
-	Endpoint               *string `json:"endpoint,omitempty"`
-	AccessKeyID            *string `json:"access_key_id,omitempty"`
-	SecretAccessKey        *string `json:"secret_access_key,omitempty"`
-	KeepDataAfterRetention *bool   `json:"keep_data_after_retention,omitempty"`
-}
-
-type collectorDatabase struct {
-	ID          *int    `json:"id,omitempty"`
-	ServiceType *string `json:"service_type,omitempty"`
-	Host        *string `json:"host,omitempty"`
-	Port        *int    `json:"port,omitempty"`
-	Username    *string `json:"username,omitempty"`
-	Password    *string `json:"password,omitempty"`
-	SSLMode     *string `json:"ssl_mode,omitempty"`
-	TLS         *string `json:"tls,omitempty"`
-	Destroy     *bool   `json:"_destroy,omitempty"`
-}
-
-type collector struct {
-	Name                    *string                 `json:"name,omitempty"`
-	Platform                *string                 `json:"platform,omitempty"`
-	Note                    *string                 `json:"note,omitempty"`
-	Status                  *string                 `json:"status,omitempty"`
-	Secret                  *string                 `json:"secret,omitempty"`
-	SourceID                *int                    `json:"source_id,omitempty"`
-	SourceGroupID           *int                    `json:"source_group_id,omitempty"`
-	LiveTailPattern         *string                 `json:"live_tail_pattern,omitempty"`
-	DataRegion              *string                 `json:"data_region,omitempty"`
-	TeamID                  *StringOrInt            `json:"team_id,omitempty"`
-	TeamName                *string                 `json:"team_name,omitempty"`
-	LogsRetention           *int                    `json:"logs_retention,omitempty"`
-	MetricsRetention        *int                    `json:"metrics_retention,omitempty"`
-	IngestingPaused         *bool                   `json:"ingesting_paused,omitempty"`
-	HostsCount              *int                    `json:"hosts_count,omitempty"`
-	HostsUpCount            *int                    `json:"hosts_up_count,omitempty"`
-	DatabasesCount          *int                    `json:"databases_count,omitempty"`
-	PingedAt                *string                 `json:"pinged_at,omitempty"`
-	CreatedAt               *string                 `json:"created_at,omitempty"`
-	UpdatedAt               *string                 `json:"updated_at,omitempty"`
-	UserVectorConfig        *string                 `json:"user_vector_config,omitempty"`
-	SourceVrlTransformation *string                 `json:"source_vrl_transformation,omitempty"`
-	Configuration           *collectorConfiguration `json:"configuration,omitempty"`
-	CustomBucket            *collectorCustomBucket  `json:"custom_bucket,omitempty"`
-	Databases               *[]collectorDatabase    `json:"databases,omitempty"`
-}
+// API structs, see the betterstack package.
 
-type collectorHTTPResponse struct {
-	Data struct {
-		ID         string    `json:"id"`
-		Attributes collector `json:"attributes"`
-	} `json:"data"`
-}
+type (
+	collectorComponents    = betterstack.CollectorComponents
+	collectorEntityOption  = betterstack.CollectorEntityOption
+	collectorConfiguration = betterstack.CollectorConfiguration
+	collectorCustomBucket  = betterstack.CollectorCustomBucket
+	collectorDatabase      = betterstack.CollectorDatabase
+	collector              = betterstack.Collector
+)
 
-type collectorPageHTTPResponse struct {
-	Data []struct {
-		ID         string    `json:"id"`
-		Attributes collector `json:"attributes"`
-	} `json:"data"`
-	Pagination struct {
-		Next string `json:"next"`
-	} `json:"pagination"`
-}
+type collectorHTTPResponse = betterstack.Response[collector]
 
 type collectorDatabasesHTTPResponse struct {
 	Data []struct {
@@ -668,8 +589,13 @@ func newCollectorResource() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateCollector),
-		Description:   "This resource allows you to create, modify, and delete Better Stack Collectors. For more information about the Collectors API check https://betterstack.com/docs/logs/api/collectors/",
-		Schema:        collectorSchema,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validation.PreferWriteOnlyAttribute(customBucketAttrPath("secret_access_key"), customBucketAttrPath("secret_access_key_wo")),
+			validation.PreferWriteOnlyAttribute(collectorDatabaseAttrPath("password"), collectorDatabaseAttrPath("password_wo")),
+			validateCollectorDatabasesPasswordWO,
+		},
+		Description: "This resource allows you to create, modify, and delete Better Stack Collectors. For more information about the Collectors API check https://betterstack.com/docs/logs/api/collectors/",
+		Schema:      collectorSchema,
 	}
 }
 
@@ -784,6 +710,7 @@ func collectorCreate(ctx context.Context, d *schema.ResourceData, meta interface
 		}
 	}
 	load(d, "team_name", &in.TeamName)
+	applyDefaultTeamName(d, meta, &in.TeamName)
 
 	// Load configuration
 	in.Configuration = loadCollectorConfiguration(d)
@@ -796,7 +723,7 @@ func collectorCreate(ctx context.Context, d *schema.ResourceData, meta interface
 			in.CustomBucket = &collectorCustomBucket{
 				Endpoint:               stringPtr(cbm["endpoint"].(string)),
 				AccessKeyID:            stringPtr(cbm["access_key_id"].(string)),
-				SecretAccessKey:        stringPtr(cbm["secret_access_key"].(string)),
+				SecretAccessKey:        customBucketSecretAccessKey(d, cbm),
 				KeepDataAfterRetention: boolPtr(cbm["keep_data_after_retention"].(bool)),
 			}
 			// name is passed along when set, but the API ignores it and stores the bucket
@@ -823,6 +750,9 @@ func collectorCreate(ctx context.Context, d *schema.ResourceData, meta interface
 				SSLMode:     stringPtrIfSet(dbMap, "ssl_mode"),
 				TLS:         stringPtrIfSet(dbMap, "tls"),
 			}
+			if v := writeOnlyStringFromResourceData(d, cty.GetAttrPath("databases").IndexInt(len(databases)).GetAttr("password_wo")); v != nil {
+				db.Password = v
+			}
 			databases = append(databases, db)
 		}
 		in.Databases = &databases
@@ -920,7 +850,7 @@ func collectorUpdate(ctx context.Context, d *schema.ResourceData, meta interface
 	// Handle databases update with delta computation
 	if d.HasChange("databases") {
 		oldData, newData := d.GetChange("databases")
-		in.Databases = computeDatabasesDelta(oldData.([]interface{}), newData.([]interface{}))
+		in.Databases = computeDatabasesDelta(oldData.([]interface{}), newData.([]interface{}), collectorDatabasesRotatedPasswords(d, oldData.([]interface{}), newData.([]interface{})))
 	}
 
 	return resourceUpdate(ctx, meta, fmt.Sprintf("/api/v1/collectors/%s", url.PathEscape(d.Id())), &in)
@@ -1142,20 +1072,29 @@ func collectorCopyAttrs(d *schema.ResourceData, in *collector) diag.Diagnostics
 		}
 	}
 
-	// Copy databases (preserve passwords from state - API doesn't return them)
+	// Copy databases (preserve passwords and password versions from state - API doesn't return them)
 	if in.Databases != nil {
 		// Build maps for password preservation: by ID and by index
 		existingPasswordsByID := make(map[int]string)
 		existingPasswordsByIndex := make(map[int]string)
+		existingVersionsByID := make(map[int]int)
+		existingVersionsByIndex := make(map[int]int)
 		if existingDatabases, ok := d.GetOk("databases"); ok {
 			for i, dbData := range existingDatabases.([]interface{}) {
 				dbMap := dbData.(map[string]interface{})
+				id, _ := dbMap["id"].(int)
 				if password, ok := dbMap["password"].(string); ok && password != "" {
 					existingPasswordsByIndex[i] = password
-					if id, ok := dbMap["id"].(int); ok && id != 0 {
+					if id != 0 {
 						existingPasswordsByID[id] = password
 					}
 				}
+				if version, ok := dbMap["password_wo_version"].(int); ok && version != 0 {
+					existingVersionsByIndex[i] = version
+					if id != 0 {
+						existingVersionsByID[id] = version
+					}
+				}
 			}
 		}
 
@@ -1194,6 +1133,16 @@ func collectorCopyAttrs(d *schema.ResourceData, in *collector) diag.Diagnostics
 					dbData["password"] = password
 				}
 			}
+			if db.ID != nil {
+				if version, ok := existingVersionsByID[*db.ID]; ok {
+					dbData["password_wo_version"] = version
+				}
+			}
+			if _, hasVersion := dbData["password_wo_version"]; !hasVersion {
+				if version, ok := existingVersionsByIndex[i]; ok {
+					dbData["password_wo_version"] = version
+				}
+			}
 			databasesData = append(databasesData, dbData)
 		}
 		if err := d.Set("databases", databasesData); err != nil {
@@ -1212,8 +1161,81 @@ func validateCollector(ctx context.Context, diff *schema.ResourceDiff, v interfa
 	return validateCustomBucketChange(ctx, diff, v)
 }
 
+func collectorDatabaseAttrPath(attr string) cty.Path {
+	return cty.GetAttrPath("databases").Index(cty.UnknownVal(cty.Number)).GetAttr(attr)
+}
+
+// validateCollectorDatabasesPasswordWO checks the password attributes of every databases block:
+// password and password_wo conflict, and password_wo requires password_wo_version. ConflictsWith
+// and RequiredWith can't express this, they only address the first element of a list.
+func validateCollectorDatabasesPasswordWO(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	if req.RawConfig.IsNull() || !req.RawConfig.IsKnown() {
+		return
+	}
+	databases := req.RawConfig.GetAttr("databases")
+	if databases.IsNull() || !databases.IsKnown() {
+		return
+	}
+	for it := databases.ElementIterator(); it.Next(); {
+		key, db := it.Element()
+		if db.IsNull() || !db.IsKnown() {
+			continue
+		}
+		path := cty.GetAttrPath("databases").Index(key)
+		passwordWO := db.GetAttr("password_wo")
+		if passwordWO.IsNull() {
+			if !db.GetAttr("password_wo_version").IsNull() {
+				resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "Missing write-only password",
+					Detail:        "password_wo_version requires password_wo to be set in the same databases block.",
+					AttributePath: path.GetAttr("password_wo_version"),
+				})
+			}
+			continue
+		}
+		if !db.GetAttr("password").IsNull() {
+			resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Conflicting passwords",
+				Detail:        "Only one of password and password_wo can be set in a databases block.",
+				AttributePath: path.GetAttr("password_wo"),
+			})
+		}
+		if db.GetAttr("password_wo_version").IsNull() {
+			resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Missing write-only password version",
+				Detail:        "password_wo requires password_wo_version to be set in the same databases block, increment it to send a changed password.",
+				AttributePath: path.GetAttr("password_wo_version"),
+			})
+		}
+	}
+}
+
+// collectorDatabasesRotatedPasswords returns the password_wo values to send on update, by index
+// in the new databases list: those of added databases and of databases whose password_wo_version
+// changed. Other write-only passwords are left unchanged on the API.
+func collectorDatabasesRotatedPasswords(d *schema.ResourceData, oldDatabases, newDatabases []interface{}) map[int]string {
+	passwords := make(map[int]string)
+	for i, dbData := range newDatabases {
+		version, _ := dbData.(map[string]interface{})["password_wo_version"].(int)
+		if i < len(oldDatabases) {
+			oldVersion, _ := oldDatabases[i].(map[string]interface{})["password_wo_version"].(int)
+			if oldVersion == version {
+				continue
+			}
+		}
+		if v := writeOnlyStringFromResourceData(d, cty.GetAttrPath("databases").IndexInt(i).GetAttr("password_wo")); v != nil {
+			passwords[i] = *v
+		}
+	}
+	return passwords
+}
+
 // computeDatabasesDelta calculates the delta between old and new databases for update.
-func computeDatabasesDelta(oldDatabases, newDatabases []interface{}) *[]collectorDatabase {
+// passwordsWO holds the write-only passwords to send, by index in newDatabases.
+func computeDatabasesDelta(oldDatabases, newDatabases []interface{}, passwordsWO map[int]string) *[]collectorDatabase {
 	oldByID := make(map[int]map[string]interface{})
 	for _, dbData := range oldDatabases {
 		dbMap := dbData.(map[string]interface{})
@@ -1236,6 +1258,9 @@ func computeDatabasesDelta(oldDatabases, newDatabases []interface{}) *[]collecto
 			SSLMode:     stringPtrIfSet(dbMap, "ssl_mode"),
 			TLS:         stringPtrIfSet(dbMap, "tls"),
 		}
+		if password, ok := passwordsWO[i]; ok {
+			db.Password = stringPtr(password)
+		}
 
 		// Match by position to preserve IDs
 		if i < len(oldDatabases) {
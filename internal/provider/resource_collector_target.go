@@ -7,6 +7,8 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -49,6 +51,12 @@ var collectorTargetCheckableFields = []string{
 	"scheme", "ssl_mode", "tls", "service", "listen_ip", "endpoint",
 }
 
+// Write-only alternatives of checkable fields, validated against the same per-kind whitelist.
+var collectorTargetWriteOnlyFields = map[string]string{
+	"password": "password_wo",
+	"api_key":  "api_key_wo",
+}
+
 var collectorTargetSchema = map[string]*schema.Schema{
 	"id": {
 		Description: "The ID of this target.",
@@ -110,16 +118,48 @@ var collectorTargetSchema = map[string]*schema.Schema{
 		Optional:    true,
 	},
 	"password": {
-		Description: "Password for authentication. Used by database kinds.",
-		Type:        schema.TypeString,
-		Optional:    true,
-		Sensitive:   true,
+		Description:   "Password for authentication. Used by database kinds.",
+		Type:          schema.TypeString,
+		Optional:      true,
+		Sensitive:     true,
+		ConflictsWith: []string{"password_wo"},
+	},
+	"password_wo": {
+		Description:   "Write-only alternative to `password`, never stored in the Terraform plan or state. Requires Terraform 1.11 or later. Increment `password_wo_version` to send a changed password.",
+		Type:          schema.TypeString,
+		Optional:      true,
+		Sensitive:     true,
+		WriteOnly:     true,
+		ConflictsWith: []string{"password"},
+		RequiredWith:  []string{"password_wo_version"},
+	},
+	"password_wo_version": {
+		Description:  "Version of `password_wo`. Change it to update the password on the target.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		RequiredWith: []string{"password_wo"},
 	},
 	"api_key": {
-		Description: "API key for authentication. Used by elasticsearch.",
-		Type:        schema.TypeString,
-		Optional:    true,
-		Sensitive:   true,
+		Description:   "API key for authentication. Used by elasticsearch.",
+		Type:          schema.TypeString,
+		Optional:      true,
+		Sensitive:     true,
+		ConflictsWith: []string{"api_key_wo"},
+	},
+	"api_key_wo": {
+		Description:   "Write-only alternative to `api_key`, never stored in the Terraform plan or state. Requires Terraform 1.11 or later. Increment `api_key_wo_version` to send a changed API key.",
+		Type:          schema.TypeString,
+		Optional:      true,
+		Sensitive:     true,
+		WriteOnly:     true,
+		ConflictsWith: []string{"api_key"},
+		RequiredWith:  []string{"api_key_wo_version"},
+	},
+	"api_key_wo_version": {
+		Description:  "Version of `api_key_wo`. Change it to update the API key on the target.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		RequiredWith: []string{"api_key_wo"},
 	},
 	"ssl_mode": {
 		Description:  "SSL mode. Required for postgres-family kinds. Valid values: `disable`, `require`, `verify-ca`.",
@@ -161,32 +201,9 @@ var collectorTargetSchema = map[string]*schema.Schema{
 	},
 }
 
-type collectorTarget struct {
-	Kind      *string `json:"kind,omitempty"`
-	Host      *string `json:"host,omitempty"`
-	Port      *int    `json:"port,omitempty"`
-	Service   *string `json:"service,omitempty"`
-	ListenIP  *string `json:"listen_ip,omitempty"`
-	Endpoint  *string `json:"endpoint,omitempty"`
-	Scheme    *string `json:"scheme,omitempty"`
-	Username  *string `json:"username,omitempty"`
-	Password  *string `json:"password,omitempty"`
-	APIKey    *string `json:"api_key,omitempty"`
-	SSLMode   *string `json:"ssl_mode,omitempty"`
-	TLS       *string `json:"tls,omitempty"`
-	Enabled   *bool   `json:"enabled,omitempty"`
-	Status    *string `json:"status,omitempty"`
-	Container *string `json:"container,omitempty"`
-	CreatedAt *string `json:"created_at,omitempty"`
-	UpdatedAt *string `json:"updated_at,omitempty"`
-}
+type collectorTarget = betterstack.CollectorTarget
 
-type collectorTargetHTTPResponse struct {
-	Data struct {
-		ID         string          `json:"id"`
-		Attributes collectorTarget `json:"attributes"`
-	} `json:"data"`
-}
+type collectorTargetHTTPResponse = betterstack.Response[collectorTarget]
 
 func newCollectorTargetResource() *schema.Resource {
 	return &schema.Resource{
@@ -198,8 +215,12 @@ func newCollectorTargetResource() *schema.Resource {
 			StateContext: collectorTargetImport,
 		},
 		CustomizeDiff: validateCollectorTarget,
-		Description:   "Manages a single 'Collect metrics' target on a Better Stack Collector - a database (postgres, pgbouncer, mysql, redis, mongodb, memcached, elasticsearch) or process exporter (nginx, apache, kafka, prometheus, traefik) that the collector scrapes.",
-		Schema:        collectorTargetSchema,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validation.PreferWriteOnlyAttribute(cty.GetAttrPath("password"), cty.GetAttrPath("password_wo")),
+			validation.PreferWriteOnlyAttribute(cty.GetAttrPath("api_key"), cty.GetAttrPath("api_key_wo")),
+		},
+		Description: "Manages a single 'Collect metrics' target on a Better Stack Collector - a database (postgres, pgbouncer, mysql, redis, mongodb, memcached, elasticsearch) or process exporter (nginx, apache, kafka, prometheus, traefik) that the collector scrapes.",
+		Schema:      collectorTargetSchema,
 	}
 }
 
@@ -254,6 +275,9 @@ func validateCollectorTarget(ctx context.Context, diff *schema.ResourceDiff, v i
 		if allowed[field] {
 			continue
 		}
+		if wo, ok := collectorTargetWriteOnlyFields[field]; ok && writeOnlyConfigured(diff, cty.GetAttrPath(wo)) {
+			return fmt.Errorf("%s is not valid for kind %q", wo, kind)
+		}
 		switch val := diff.Get(field).(type) {
 		case string:
 			if val != "" {
@@ -277,10 +301,12 @@ func collectorTargetMemberURL(collectorID, id string) string {
 	return fmt.Sprintf("/api/v1/collectors/%s/targets/%s", url.PathEscape(collectorID), url.PathEscape(id))
 }
 
-func collectorTargetBuildRequest(d *schema.ResourceData, includeKind bool) collectorTarget {
+// collectorTargetBuildRequest builds the create (create = true) or update request. Write-only
+// secrets are only sent on create or when their version counter changed.
+func collectorTargetBuildRequest(d *schema.ResourceData, create bool) collectorTarget {
 	var in collectorTarget
 	kind := d.Get("kind").(string)
-	if includeKind {
+	if create {
 		in.Kind = stringPtr(kind)
 	}
 
@@ -319,6 +345,16 @@ func collectorTargetBuildRequest(d *schema.ResourceData, includeKind bool) colle
 	if v := d.Get("api_key").(string); v != "" {
 		in.APIKey = stringPtr(v)
 	}
+	if create || d.HasChange("password_wo_version") {
+		if v := writeOnlyStringFromResourceData(d, cty.GetAttrPath("password_wo")); v != nil {
+			in.Password = v
+		}
+	}
+	if create || d.HasChange("api_key_wo_version") {
+		if v := writeOnlyStringFromResourceData(d, cty.GetAttrPath("api_key_wo")); v != nil {
+			in.APIKey = v
+		}
+	}
 	if v := d.Get("ssl_mode").(string); v != "" {
 		in.SSLMode = stringPtr(v)
 	}
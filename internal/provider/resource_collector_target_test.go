@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -229,6 +230,91 @@ func TestResourceCollectorTargetElasticsearch(t *testing.T) {
 	})
 }
 
+func TestResourceCollectorTargetWriteOnlyPassword(t *testing.T) {
+	server, _ := newCollectorTargetMockServer(t, "77", "9")
+	defer server.Close()
+
+	// Record the password of every request before the mock server handles it.
+	var passwords []string
+	handler := server.Config.Handler
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost || r.Method == http.MethodPatch {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var in struct {
+				Password *string `json:"password"`
+			}
+			if err := json.Unmarshal(body, &in); err != nil {
+				t.Fatal(err)
+			}
+			password := ""
+			if in.Password != nil {
+				password = *in.Password
+			}
+			passwords = append(passwords, r.Method+" "+password)
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		handler.ServeHTTP(w, r)
+	})
+
+	config := func(host, password string, version int) string {
+		return fmt.Sprintf(`
+		provider "logtail" {
+			api_token = "foo"
+		}
+
+		resource "logtail_collector_target" "db" {
+			collector_id        = "77"
+			kind                = "postgres"
+			host                = %q
+			port                = 5432
+			username            = "monitor"
+			password_wo         = %q
+			password_wo_version = %d
+			ssl_mode            = "require"
+		}
+		`, host, password, version)
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"logtail": func() (*schema.Provider, error) {
+				return New(WithURL(server.URL)), nil
+			},
+		},
+		Steps: []resource.TestStep{
+			// Step 1: create - the write-only password is sent but never stored.
+			{
+				Config: config("db.example.com", "secret", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("logtail_collector_target.db", "password_wo_version", "1"),
+					resource.TestCheckNoResourceAttr("logtail_collector_target.db", "password_wo"),
+					resource.TestCheckNoResourceAttr("logtail_collector_target.db", "password"),
+				),
+			},
+			// Step 2: update host only - the password is not sent again.
+			{
+				Config: config("db-new.example.com", "secret", 1),
+				Check:  resource.TestCheckResourceAttr("logtail_collector_target.db", "host", "db-new.example.com"),
+			},
+			// Step 3: bump the version - the rotated password is sent.
+			{
+				Config: config("db-new.example.com", "rotated", 2),
+				Check: func(s *terraform.State) error {
+					expected := []string{"POST secret", "PATCH ", "PATCH rotated"}
+					if fmt.Sprint(passwords) != fmt.Sprint(expected) {
+						return fmt.Errorf("expected passwords %v, got %v", expected, passwords)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
 func TestResourceCollectorTargetPgbouncer(t *testing.T) {
 	server, _ := newCollectorTargetMockServer(t, "77", "8")
 	defer server.Close()
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,8 +12,10 @@ import (
 	"sync/atomic"
 	"testing"
 
+	"github.com/betterstackhq/terraform-provider-logtail/internal/fakeapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestResourceCollector(t *testing.T) {
@@ -970,6 +973,120 @@ func processDatabasesUpdate(t *testing.T, body json.RawMessage) json.RawMessage
 	return body
 }
 
+func TestResourceCollectorDatabasesWriteOnlyPassword(t *testing.T) {
+	api := fakeapi.New(t)
+
+	// Record the database passwords of every request before the fake API drops them.
+	var passwords []string
+	handler := api.Config.Handler
+	api.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost || r.Method == http.MethodPatch {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var in struct {
+				Databases []struct {
+					Password *string `json:"password"`
+				} `json:"databases"`
+			}
+			if err := json.Unmarshal(body, &in); err != nil {
+				t.Fatal(err)
+			}
+			for _, db := range in.Databases {
+				password := ""
+				if db.Password != nil {
+					password = *db.Password
+				}
+				passwords = append(passwords, r.Method+" "+password)
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		handler.ServeHTTP(w, r)
+	})
+
+	config := func(host, password string, version int) string {
+		return fmt.Sprintf(`
+		provider "logtail" {
+			api_token = "foo"
+		}
+
+		resource "logtail_collector" "this" {
+			name     = "Test Collector"
+			platform = "docker"
+
+			databases {
+				service_type        = "postgres"
+				host                = %q
+				port                = 5432
+				username            = "collector"
+				password_wo         = %q
+				password_wo_version = %d
+			}
+		}
+		`, host, password, version)
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"logtail": func() (*schema.Provider, error) {
+				return New(WithURL(api.URL)), nil
+			},
+		},
+		Steps: []resource.TestStep{
+			// Step 1: password and password_wo conflict, password_wo requires a version.
+			{
+				Config: `
+				provider "logtail" {
+					api_token = "foo"
+				}
+
+				resource "logtail_collector" "this" {
+					name     = "Test Collector"
+					platform = "docker"
+
+					databases {
+						service_type = "postgres"
+						host         = "db.example.com"
+						port         = 5432
+						password     = "secret"
+						password_wo  = "secret"
+					}
+				}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Conflicting passwords`),
+			},
+			// Step 2: create - the write-only password is sent but never stored.
+			{
+				Config: config("db.example.com", "secret", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("logtail_collector.this", "databases.0.password_wo_version", "1"),
+					resource.TestCheckNoResourceAttr("logtail_collector.this", "databases.0.password_wo"),
+					resource.TestCheckResourceAttr("logtail_collector.this", "databases.0.password", ""),
+				),
+			},
+			// Step 3: update host only - the password is not sent again.
+			{
+				Config: config("db-new.example.com", "secret", 1),
+				Check:  resource.TestCheckResourceAttr("logtail_collector.this", "databases.0.host", "db-new.example.com"),
+			},
+			// Step 4: bump the version - the rotated password is sent.
+			{
+				Config: config("db-new.example.com", "rotated", 2),
+				Check: func(s *terraform.State) error {
+					expected := []string{"POST secret", "PATCH ", "PATCH rotated"}
+					if fmt.Sprint(passwords) != fmt.Sprint(expected) {
+						return fmt.Errorf("expected passwords %v, got %v", expected, passwords)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
 func TestResourceCollectorNewFeatures(t *testing.T) {
 	var collectorData atomic.Value
 	var databasesData atomic.Value
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"slices"
 
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -87,7 +88,7 @@ var connectionSchema = map[string]*schema.Schema{
 		Computed:    true,
 	},
 	"password": {
-		Description: "The connection password. Only available immediately after creation.",
+		Description: "The connection password. Only available immediately after creation. Use the `logtail_connection` ephemeral resource to keep the password out of the Terraform state.",
 		Type:        schema.TypeString,
 		Computed:    true,
 		Sensitive:   true,
@@ -142,30 +143,9 @@ var connectionDataSourceSchema = map[string]*schema.Schema{
 	},
 }
 
-type connection struct {
-	ClientType  *string                   `json:"client_type,omitempty"`
-	TeamNames   *[]string                 `json:"team_names,omitempty"`
-	TeamIds     *[]int                    `json:"team_ids,omitempty"`
-	DataRegion  *string                   `json:"data_region,omitempty"`
-	IpAllowlist *[]string                 `json:"ip_allowlist,omitempty"`
-	ValidUntil  *string                   `json:"valid_until,omitempty"`
-	Note        *string                   `json:"note,omitempty"`
-	Host        *string                   `json:"host,omitempty"`
-	Port        *int                      `json:"port,omitempty"`
-	Username    *string                   `json:"username,omitempty"`
-	Password    *string                   `json:"password,omitempty"`
-	CreatedAt   *string                   `json:"created_at,omitempty"`
-	CreatedBy   map[string]interface{}    `json:"created_by,omitempty"`
-	SampleQuery *string                   `json:"sample_query,omitempty"`
-	DataSources *[]map[string]interface{} `json:"data_sources,omitempty"`
-}
+type connection = betterstack.Connection
 
-type connectionHTTPResponse struct {
-	Data struct {
-		ID         string     `json:"id"`
-		Attributes connection `json:"attributes"`
-	} `json:"data"`
-}
+type connectionHTTPResponse = betterstack.Response[connection]
 
 func connectionRef(in *connection) []struct {
 	k string
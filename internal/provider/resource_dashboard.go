@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/url"
 
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -31,7 +32,7 @@ var dashboardSchema = map[string]*schema.Schema{
 		ForceNew:    true,
 	},
 	"team_name": {
-		Description: "The team name to associate with the dashboard when using a global API token. You can't update this value later.",
+		Description: "The team name to associate with the dashboard when using a global API token. Defaults to the provider's `default_team_name`. You can't update this value later.",
 		Type:        schema.TypeString,
 		Optional:    true,
 		Default:     nil,
@@ -81,10 +82,11 @@ var dashboardSchema = map[string]*schema.Schema{
 		Computed:    true,
 	},
 	"source_eligibility_sql": {
-		Description: "SQL expression to filter eligible sources.",
-		Type:        schema.TypeString,
-		Optional:    true,
-		Computed:    true,
+		Description:      "SQL expression to filter eligible sources.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		ValidateDiagFunc: validateSQLExpression,
 	},
 	"variable": {
 		Description: "Variables for this dashboard. Default variables (time, start_time, end_time, source) are auto-created.",
@@ -118,10 +120,11 @@ var dashboardSchema = map[string]*schema.Schema{
 					Elem:        &schema.Schema{Type: schema.TypeString},
 				},
 				"sql_definition": {
-					Description: "SQL definition for 'select_with_sql' or 'multi_select_with_sql' type variables.",
-					Type:        schema.TypeString,
-					Optional:    true,
-					Computed:    true,
+					Description:      "SQL definition for 'select_with_sql' or 'multi_select_with_sql' type variables.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					Computed:         true,
+					ValidateDiagFunc: validateSQLExpression,
 				},
 			},
 		},
@@ -138,36 +141,12 @@ var dashboardSchema = map[string]*schema.Schema{
 	},
 }
 
-type dashboardVariable struct {
-	Name          *string  `json:"name,omitempty"`
-	VariableType  *string  `json:"variable_type,omitempty"`
-	Values        []string `json:"values,omitempty"`
-	DefaultValues []string `json:"default_values,omitempty"`
-	SQLDefinition *string  `json:"sql_definition,omitempty"`
-}
-
-type dashboard struct {
-	Name                 *string             `json:"name,omitempty"`
-	Data                 interface{}         `json:"data,omitempty"`
-	TeamName             *string             `json:"team_name,omitempty"`
-	TeamId               *int                `json:"team_id,omitempty"`
-	DashboardGroupID     *int                `json:"dashboard_group_id,omitempty"`
-	RefreshInterval      *int                `json:"refresh_interval,omitempty"`
-	DateRangeFrom        *string             `json:"date_range_from,omitempty"`
-	DateRangeTo          *string             `json:"date_range_to,omitempty"`
-	SourceEligibilitySQL *string             `json:"source_eligibility_sql,omitempty"`
-	Variables            []dashboardVariable `json:"variables,omitempty"`
-	CreatedAt            *string             `json:"created_at,omitempty"`
-	UpdatedAt            *string             `json:"updated_at,omitempty"`
-}
+type (
+	dashboardVariable = betterstack.DashboardVariable
+	dashboard         = betterstack.Dashboard
+)
 
-type dashboardHTTPResponse struct {
-	Data struct {
-		ID         string    `json:"id"`
-		Type       string    `json:"type"`
-		Attributes dashboard `json:"attributes"`
-	} `json:"data"`
-}
+type dashboardHTTPResponse = betterstack.Response[dashboard]
 
 type dashboardExportResponse struct {
 	ID   StringOrInt            `json:"id"`
@@ -184,7 +163,7 @@ func newDashboardResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateDashboard),
+		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateDashboard, validateSQLVariables("source_eligibility_sql", "variable.*.sql_definition")),
 		Description:   "This resource allows you to create and manage dashboards. Use 'data' for import mode (JSON blob, re-created on change) or individual fields for CRUD mode (updatable). For more information about the Dashboard API check https://betterstack.com/docs/logs/api/dashboards/",
 		Schema:        dashboardSchema,
 	}
@@ -249,6 +228,7 @@ func dashboardCreateImportMode(ctx context.Context, d *schema.ResourceData, meta
 	var in dashboard
 	load(d, "name", &in.Name)
 	load(d, "team_name", &in.TeamName)
+	applyDefaultTeamName(d, meta, &in.TeamName)
 
 	userData := d.Get("data").(string)
 	var dataObj interface{}
@@ -258,7 +238,8 @@ func dashboardCreateImportMode(ctx context.Context, d *schema.ResourceData, meta
 	in.Data = dataObj
 
 	var out dashboardHTTPResponse
-	if err := resourceCreate(ctx, meta, "/api/v2/dashboards/import", &in, &out); err != nil {
+	// The import endpoint isn't a collection, a dashboard it may have created is listed with the others.
+	if err := resourceCreate(betterstack.WithCreateListPath(ctx, "/api/v2/dashboards"), meta, "/api/v2/dashboards/import", &in, &out); err != nil {
 		return err
 	}
 	d.SetId(out.Data.ID)
@@ -288,6 +269,7 @@ func dashboardCreateImportMode(ctx context.Context, d *schema.ResourceData, meta
 
 func dashboardCreateCRUDMode(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	in := loadDashboardCRUD(d)
+	applyDefaultTeamName(d, meta, &in.TeamName)
 
 	var out dashboardHTTPResponse
 	if err := resourceCreate(ctx, meta, "/api/v2/dashboards", &in, &out); err != nil {
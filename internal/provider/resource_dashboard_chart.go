@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -117,10 +118,11 @@ var dashboardChartSchema = map[string]*schema.Schema{
 					ValidateFunc: validation.StringInSlice([]string{"sql_expression", "tail_query", "static_text", "pql_expression", "query_builder", "funnel_query"}, false),
 				},
 				"sql_query": {
-					Description: "The SQL query string. Required when query_type is 'sql_expression'.",
-					Type:        schema.TypeString,
-					Optional:    true,
-					Computed:    true,
+					Description:      "The SQL query string. Required when query_type is 'sql_expression'. Its syntax is checked at plan time, but its `{{variable}}` references are only checked by Better Stack on apply: the variables are declared on the parent `logtail_dashboard`, whose variable blocks can be added or changed in the same plan and aren't readable from the chart until then.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					Computed:         true,
+					ValidateDiagFunc: validateSQLQuery,
 				},
 				"where_condition": {
 					Description: "The WHERE condition for filtering. Required when query_type is 'tail_query'.",
@@ -171,36 +173,12 @@ func newDashboardChartResource() *schema.Resource {
 	}
 }
 
-type dashboardChartQuery struct {
-	ID             *int    `json:"id,omitempty"`
-	Name           *string `json:"name,omitempty"`
-	QueryType      *string `json:"query_type,omitempty"`
-	SQLQuery       *string `json:"sql_query,omitempty"`
-	WhereCondition *string `json:"where_condition,omitempty"`
-	StaticText     *string `json:"static_text,omitempty"`
-	SourceVariable *string `json:"source_variable,omitempty"`
-}
-
-type dashboardChart struct {
-	ChartType   *string                `json:"chart_type,omitempty"`
-	Name        *string                `json:"name,omitempty"`
-	Description *string                `json:"description,omitempty"`
-	X           *int                   `json:"x,omitempty"`
-	Y           *int                   `json:"y,omitempty"`
-	W           *int                   `json:"w,omitempty"`
-	H           *int                   `json:"h,omitempty"`
-	Settings    map[string]interface{} `json:"settings,omitempty"`
-	Queries     []dashboardChartQuery  `json:"queries,omitempty"`
-	CreatedAt   *string                `json:"created_at,omitempty"`
-	UpdatedAt   *string                `json:"updated_at,omitempty"`
-}
+type (
+	dashboardChartQuery = betterstack.DashboardChartQuery
+	dashboardChart      = betterstack.DashboardChart
+)
 
-type dashboardChartHTTPResponse struct {
-	Data struct {
-		ID         string         `json:"id"`
-		Attributes dashboardChart `json:"attributes"`
-	} `json:"data"`
-}
+type dashboardChartHTTPResponse = betterstack.Response[dashboardChart]
 
 func dashboardChartCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	dashboardID := d.Get("dashboard_id").(string)
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"reflect"
 
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -52,19 +53,9 @@ func newDashboardGroupResource() *schema.Resource {
 	}
 }
 
-type dashboardGroup struct {
-	Name      *string `json:"name,omitempty"`
-	CreatedAt *string `json:"created_at,omitempty"`
-	UpdatedAt *string `json:"updated_at,omitempty"`
-	TeamName  *string `json:"team_name,omitempty"`
-}
+type dashboardGroup = betterstack.DashboardGroup
 
-type dashboardGroupHTTPResponse struct {
-	Data struct {
-		ID         string         `json:"id"`
-		Attributes dashboardGroup `json:"attributes"`
-	} `json:"data"`
-}
+type dashboardGroupHTTPResponse = betterstack.Response[dashboardGroup]
 
 func dashboardGroupRef(in *dashboardGroup) []struct {
 	k string
@@ -87,6 +78,7 @@ func dashboardGroupCreate(ctx context.Context, d *schema.ResourceData, meta inte
 	}
 
 	load(d, "team_name", &in.TeamName)
+	applyDefaultTeamName(d, meta, &in.TeamName)
 
 	var out dashboardGroupHTTPResponse
 	if err := resourceCreate(ctx, meta, "/api/v2/dashboard-groups", &in, &out); err != nil {
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -73,21 +74,9 @@ func newDashboardSectionResource() *schema.Resource {
 	}
 }
 
-type dashboardSection struct {
-	Name        *string `json:"name,omitempty"`
-	Y           *int    `json:"y,omitempty"`
-	Collapsed   *bool   `json:"collapsed,omitempty"`
-	Explanation *string `json:"explanation,omitempty"`
-	CreatedAt   *string `json:"created_at,omitempty"`
-	UpdatedAt   *string `json:"updated_at,omitempty"`
-}
+type dashboardSection = betterstack.DashboardSection
 
-type dashboardSectionHTTPResponse struct {
-	Data struct {
-		ID         string           `json:"id"`
-		Attributes dashboardSection `json:"attributes"`
-	} `json:"data"`
-}
+type dashboardSectionHTTPResponse = betterstack.Response[dashboardSection]
 
 func dashboardSectionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	dashboardID := d.Get("dashboard_id").(string)
@@ -2,15 +2,12 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"reflect"
 	"strings"
 
-	"github.com/hashicorp/go-cty/cty"
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -153,26 +150,11 @@ var errorsApplicationSchema = map[string]*schema.Schema{
 		Computed:    true,
 	},
 	"platform": {
-		Description: "The platform type for the application. This helps configure appropriate SDKs and integrations. You can't update this value later. Valid values are:\n    - `" + strings.Join(errorsPlatformTypes, "`\n    - `") + "`",
-		Type:        schema.TypeString,
-		Required:    true,
-		ForceNew:    true,
-		ValidateDiagFunc: func(v interface{}, path cty.Path) diag.Diagnostics {
-			s := v.(string)
-			for _, platformType := range errorsPlatformTypes {
-				if s == platformType {
-					return nil
-				}
-			}
-			return diag.Diagnostics{
-				diag.Diagnostic{
-					AttributePath: path,
-					Severity:      diag.Error,
-					Summary:       `Invalid "platform"`,
-					Detail:        fmt.Sprintf("Expected one of %v", errorsPlatformTypes),
-				},
-			}
-		},
+		Description:  "The platform type for the application. This helps configure appropriate SDKs and integrations. You can't update this value later. Valid values are the platforms of the `logtail_platforms` data source. When the platform catalog can't be read, these are accepted:\n    - `" + strings.Join(errorsPlatformTypes, "`\n    - `") + "`",
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringIsNotEmpty,
 	},
 	"ingesting_host": {
 		Description: "The host where the errors should be sent. See documentation for your specific platform for details.",
@@ -271,6 +253,7 @@ var errorsApplicationSchema = map[string]*schema.Schema{
 		Optional:         true,
 		Computed:         true,
 		DiffSuppressFunc: suppressEquivalentVRL,
+		ValidateDiagFunc: validateVRL,
 	},
 	"vrl_transformation_replays": {
 		Description:      "VRL transformation applied to session replays on Better Stack's servers during ingestion. Leave unset to keep the transformation unmanaged; set to an empty string to remove it. Read more about [VRL transformations](https://betterstack.com/docs/logs/using-logtail/transforming-ingested-data/logs-vrl/).",
@@ -278,6 +261,7 @@ var errorsApplicationSchema = map[string]*schema.Schema{
 		Optional:         true,
 		Computed:         true,
 		DiffSuppressFunc: suppressEquivalentVRL,
+		ValidateDiagFunc: validateVRL,
 	},
 	"vrl_transformation_web_events": {
 		Description:      "VRL transformation applied to web events (page views and web vitals) on Better Stack's servers during ingestion. Leave unset to keep the transformation unmanaged; set to an empty string to remove it. Read more about [VRL transformations](https://betterstack.com/docs/logs/using-logtail/transforming-ingested-data/logs-vrl/).",
@@ -285,6 +269,7 @@ var errorsApplicationSchema = map[string]*schema.Schema{
 		Optional:         true,
 		Computed:         true,
 		DiffSuppressFunc: suppressEquivalentVRL,
+		ValidateDiagFunc: validateVRL,
 	},
 	"vrl_transformation_logs": {
 		Description:      "VRL transformation applied to logs on Better Stack's servers during ingestion. Leave unset to keep the transformation unmanaged; set to an empty string to remove it. Read more about [VRL transformations](https://betterstack.com/docs/logs/using-logtail/transforming-ingested-data/logs-vrl/).",
@@ -292,6 +277,7 @@ var errorsApplicationSchema = map[string]*schema.Schema{
 		Optional:         true,
 		Computed:         true,
 		DiffSuppressFunc: suppressEquivalentVRL,
+		ValidateDiagFunc: validateVRL,
 	},
 	"vrl_transformation_spans": {
 		Description:      "VRL transformation applied to traces (spans) on Better Stack's servers during ingestion. Leave unset to keep the transformation unmanaged; set to an empty string to remove it. Read more about [VRL transformations](https://betterstack.com/docs/logs/using-logtail/transforming-ingested-data/logs-vrl/).",
@@ -299,6 +285,7 @@ var errorsApplicationSchema = map[string]*schema.Schema{
 		Optional:         true,
 		Computed:         true,
 		DiffSuppressFunc: suppressEquivalentVRL,
+		ValidateDiagFunc: validateVRL,
 	},
 	"custom_bucket": {
 		Description: "Optional custom S3-compatible bucket configuration for the application. " +
@@ -329,11 +316,21 @@ var errorsApplicationSchema = map[string]*schema.Schema{
 					ValidateFunc: validation.StringIsNotEmpty,
 				},
 				"secret_access_key": {
-					Description:  "Secret access key",
+					Description:  "Secret access key. Exactly one of `secret_access_key` or `secret_access_key_wo` must be set.",
 					Type:         schema.TypeString,
-					Required:     true,
+					Optional:     true,
 					Sensitive:    true,
 					ValidateFunc: validation.StringIsNotEmpty,
+					ExactlyOneOf: []string{"custom_bucket.0.secret_access_key", "custom_bucket.0.secret_access_key_wo"},
+				},
+				"secret_access_key_wo": {
+					Description:  "Secret access key as a write-only attribute (requires Terraform 1.11 or later), it is sent to the API when the application is created but never stored in the state. As `custom_bucket` can't be changed after creation, there is no version attribute to rotate it.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					Sensitive:    true,
+					WriteOnly:    true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					ExactlyOneOf: []string{"custom_bucket.0.secret_access_key", "custom_bucket.0.secret_access_key_wo"},
 				},
 				"keep_data_after_retention": {
 					Description: "Whether we should keep data in the bucket after the retention period.",
@@ -355,51 +352,22 @@ func newErrorsApplicationResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateErrorsApplication,
+		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateErrorsApplication, validatePlatform(errorsPlatformTypes, errorsPlatformKinds),
 			customizeDiffRepositoryName("github_repository_name"), customizeDiffRepositoryName("gitlab_repository_name"),
 			customizeDiffVRL("vrl_transformation_exceptions"), customizeDiffVRL("vrl_transformation_replays"),
 			customizeDiffVRL("vrl_transformation_web_events"), customizeDiffVRL("vrl_transformation_logs"),
 			customizeDiffVRL("vrl_transformation_spans")),
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validation.PreferWriteOnlyAttribute(customBucketAttrPath("secret_access_key"), customBucketAttrPath("secret_access_key_wo")),
+		},
 		Description: "This resource allows you to create, modify, and delete your Errors applications. For more information about the Errors API check https://betterstack.com/docs/errors/api/applications/create/",
 		Schema:      errorsApplicationSchema,
 	}
 }
 
-type errorsApplication struct {
-	Name                  *string             `json:"name,omitempty"`
-	Token                 *string             `json:"token,omitempty"`
-	JsTagToken            *string             `json:"js_tag_token,omitempty"`
-	TeamId                *StringOrInt        `json:"team_id,omitempty"`
-	TableName             *string             `json:"table_name,omitempty"`
-	Platform              *string             `json:"platform,omitempty"`
-	IngestingHost         *string             `json:"ingesting_host,omitempty"`
-	IngestingPaused       *bool               `json:"ingesting_paused,omitempty"`
-	ErrorsRetention       *int                `json:"errors_retention,omitempty"`
-	CreatedAt             *string             `json:"created_at,omitempty"`
-	UpdatedAt             *string             `json:"updated_at,omitempty"`
-	TeamName              *string             `json:"team_name,omitempty"`
-	DataRegion            *string             `json:"data_region,omitempty"`
-	CodeMappingStackRoot  *string             `json:"code_mapping_stack_root,omitempty"`
-	CodeMappingSourceRoot *string             `json:"code_mapping_source_root,omitempty"`
-	ApplicationGroupID    *int                `json:"application_group_id,omitempty"`
-	CorrelateWithSourceID *int                `json:"correlate_with_source_id,omitempty"`
-	GithubRepositoryName  *string             `json:"github_repository_name,omitempty"`
-	GitlabRepositoryName  *string             `json:"gitlab_repository_name,omitempty"`
-	CustomBucket          *sourceCustomBucket `json:"custom_bucket,omitempty"`
+type errorsApplication = betterstack.ErrorsApplication
 
-	VrlTransformationExceptions *string `json:"vrl_transformation_exceptions,omitempty"`
-	VrlTransformationReplays    *string `json:"vrl_transformation_replays,omitempty"`
-	VrlTransformationWebEvents  *string `json:"vrl_transformation_web_events,omitempty"`
-	VrlTransformationLogs       *string `json:"vrl_transformation_logs,omitempty"`
-	VrlTransformationSpans      *string `json:"vrl_transformation_spans,omitempty"`
-}
-
-type errorsApplicationHTTPResponse struct {
-	Data struct {
-		ID         string            `json:"id"`
-		Attributes errorsApplication `json:"attributes"`
-	} `json:"data"`
-}
+type errorsApplicationHTTPResponse = betterstack.Response[errorsApplication]
 
 func errorsApplicationRef(in *errorsApplication) []struct {
 	k string
@@ -459,6 +427,7 @@ func errorsApplicationCreate(ctx context.Context, d *schema.ResourceData, meta i
 	}
 
 	load(d, "team_name", &in.TeamName)
+	applyDefaultTeamName(d, meta, &in.TeamName)
 
 	if customBucketData, ok := d.GetOk("custom_bucket"); ok {
 		customBucketList := customBucketData.([]interface{})
@@ -467,7 +436,7 @@ func errorsApplicationCreate(ctx context.Context, d *schema.ResourceData, meta i
 			in.CustomBucket = &sourceCustomBucket{
 				Endpoint:               stringPtr(customBucketMap["endpoint"].(string)),
 				AccessKeyID:            stringPtr(customBucketMap["access_key_id"].(string)),
-				SecretAccessKey:        stringPtr(customBucketMap["secret_access_key"].(string)),
+				SecretAccessKey:        customBucketSecretAccessKey(d, customBucketMap),
 				KeepDataAfterRetention: boolPtr(customBucketMap["keep_data_after_retention"].(bool)),
 			}
 			// name is passed along when set, but the API ignores it and stores the bucket
@@ -647,7 +616,7 @@ func validateErrorsApplication(ctx context.Context, diff *schema.ResourceDiff, v
 
 func newErrorsApplicationDataSource() *schema.Resource {
 	s := make(map[string]*schema.Schema)
-	for k, v := range errorsApplicationSchema {
+	for k, v := range withoutWriteOnly(errorsApplicationSchema) {
 		cp := *v
 		switch k {
 		case "name":
@@ -676,6 +645,9 @@ func newErrorsApplicationDataSource() *schema.Resource {
 			// ConflictsWith is invalid on computed-only attributes (data source fields), so drop it.
 			cp.ConflictsWith = nil
 		}
+		if k == "team_name" {
+			cp.Description = dataSourceTeamNameDescription
+		}
 		s[k] = &cp
 	}
 	return &schema.Resource{
@@ -685,56 +657,16 @@ func newErrorsApplicationDataSource() *schema.Resource {
 	}
 }
 
-type errorsApplicationPageHTTPResponse struct {
-	Data []struct {
-		ID         string            `json:"id"`
-		Attributes errorsApplication `json:"attributes"`
-	} `json:"data"`
-	Pagination struct {
-		First string `json:"first"`
-		Last  string `json:"last"`
-		Prev  string `json:"prev"`
-		Next  string `json:"next"`
-	} `json:"pagination"`
-}
-
 func errorsApplicationLookup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fetch := func(page int) (*errorsApplicationPageHTTPResponse, error) {
-		res, err := meta.(*client).GetWithBaseURL(ctx, meta.(*client).ErrorsBaseURL(), fmt.Sprintf("/api/v2/applications?page=%d", page))
-		if err != nil {
-			return nil, err
-		}
-		defer func() {
-			// Keep-Alive.
-			_, _ = io.Copy(io.Discard, res.Body)
-			_ = res.Body.Close()
-		}()
-		body, err := io.ReadAll(res.Body)
-		if res.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-		}
-		if err != nil {
-			return nil, err
-		}
-		var tr errorsApplicationPageHTTPResponse
-		return &tr, json.Unmarshal(body, &tr)
-	}
 	name := d.Get("name").(string)
-	page := 1
-	for {
-		res, err := fetch(page)
+	for e, err := range meta.(*client).ErrorsApplications().List(ctx) {
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		for _, e := range res.Data {
-			if *e.Attributes.Name == name {
-				d.SetId(e.ID)
-				return errorsApplicationCopyAttrs(d, &e.Attributes)
-			}
-		}
-		page++
-		if res.Pagination.Next == "" {
-			return nil
+		if *e.Attributes.Name == name {
+			d.SetId(e.ID)
+			return errorsApplicationCopyAttrs(d, &e.Attributes)
 		}
 	}
+	return nil
 }
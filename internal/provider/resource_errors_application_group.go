@@ -2,13 +2,11 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"reflect"
 
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -60,20 +58,9 @@ func newErrorsApplicationGroupResource() *schema.Resource {
 	}
 }
 
-type errorsApplicationGroup struct {
-	Name      *string `json:"name,omitempty"`
-	CreatedAt *string `json:"created_at,omitempty"`
-	UpdatedAt *string `json:"updated_at,omitempty"`
-	TeamName  *string `json:"team_name,omitempty"`
-	SortIndex *int    `json:"sort_index,omitempty"`
-}
+type errorsApplicationGroup = betterstack.ErrorsApplicationGroup
 
-type errorsApplicationGroupHTTPResponse struct {
-	Data struct {
-		ID         string                 `json:"id"`
-		Attributes errorsApplicationGroup `json:"attributes"`
-	} `json:"data"`
-}
+type errorsApplicationGroupHTTPResponse = betterstack.Response[errorsApplicationGroup]
 
 func errorsApplicationGroupRef(in *errorsApplicationGroup) []struct {
 	k string
@@ -97,6 +84,7 @@ func errorsApplicationGroupCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	load(d, "team_name", &in.TeamName)
+	applyDefaultTeamName(d, meta, &in.TeamName)
 
 	var out errorsApplicationGroupHTTPResponse
 	if err := resourceCreateWithBaseURL(ctx, meta, meta.(*client).ErrorsBaseURL(), "/api/v1/application-groups", &in, &out); err != nil {
@@ -171,6 +159,9 @@ func newErrorsApplicationGroupDataSource() *schema.Resource {
 			cp.DefaultFunc = nil
 			cp.DiffSuppressFunc = nil
 		}
+		if k == "team_name" {
+			cp.Description = dataSourceTeamNameDescription
+		}
 		s[k] = &cp
 	}
 	return &schema.Resource{
@@ -180,61 +171,21 @@ func newErrorsApplicationGroupDataSource() *schema.Resource {
 	}
 }
 
-type errorsApplicationGroupPageHTTPResponse struct {
-	Data []struct {
-		ID         string                 `json:"id"`
-		Attributes errorsApplicationGroup `json:"attributes"`
-	} `json:"data"`
-	Pagination struct {
-		First string `json:"first"`
-		Last  string `json:"last"`
-		Prev  string `json:"prev"`
-		Next  string `json:"next"`
-	} `json:"pagination"`
-}
-
 func errorsApplicationGroupLookup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fetch := func(page int) (*errorsApplicationGroupPageHTTPResponse, error) {
-		res, err := meta.(*client).GetWithBaseURL(ctx, meta.(*client).ErrorsBaseURL(), fmt.Sprintf("/api/v1/application-groups?page=%d", page))
-		if err != nil {
-			return nil, err
-		}
-		defer func() {
-			// Keep-Alive.
-			_, _ = io.Copy(io.Discard, res.Body)
-			_ = res.Body.Close()
-		}()
-		body, err := io.ReadAll(res.Body)
-		if res.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-		}
-		if err != nil {
-			return nil, err
-		}
-		var tr errorsApplicationGroupPageHTTPResponse
-		return &tr, json.Unmarshal(body, &tr)
-	}
 	name := d.Get("name").(string)
-	page := 1
-	for {
-		res, err := fetch(page)
+	for e, err := range meta.(*client).ErrorsApplicationGroups().List(ctx) {
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		for _, e := range res.Data {
-			if *e.Attributes.Name == name {
-				if d.Id() != "" {
-					return diag.Errorf("duplicate")
-				}
-				d.SetId(e.ID)
-				if derr := errorsApplicationGroupCopyAttrs(d, &e.Attributes); derr != nil {
-					return derr
-				}
+		if *e.Attributes.Name == name {
+			if d.Id() != "" {
+				return diag.Errorf("duplicate")
+			}
+			d.SetId(e.ID)
+			if derr := errorsApplicationGroupCopyAttrs(d, &e.Attributes); derr != nil {
+				return derr
 			}
-		}
-		page++
-		if res.Pagination.Next == "" {
-			return nil
 		}
 	}
+	return nil
 }
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/http/httptest"
 	"sync/atomic"
 	"testing"
 
@@ -17,7 +16,7 @@ import (
 // it as an ordinary read attribute so refresh and import mirror the remote value.
 func TestResourceErrorsApplicationPlatformFromAPI(t *testing.T) {
 	var data atomic.Value
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
 		t.Log("Received " + r.Method + " " + r.RequestURI)
 
 		if r.Header.Get("Authorization") != "Bearer foo" {
@@ -49,7 +48,7 @@ func TestResourceErrorsApplicationPlatformFromAPI(t *testing.T) {
 		default:
 			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
 		}
-	}))
+	})
 	defer server.Close()
 
 	config := `
@@ -16,7 +16,7 @@ import (
 
 func TestResourceErrorsApplication(t *testing.T) {
 	var data atomic.Value
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
 		t.Log("Received " + r.Method + " " + r.RequestURI)
 
 		if r.Header.Get("Authorization") != "Bearer foo" {
@@ -83,7 +83,7 @@ func TestResourceErrorsApplication(t *testing.T) {
 		default:
 			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
 		}
-	}))
+	})
 	defer server.Close()
 
 	var name = "Test Errors Application"
@@ -192,7 +192,7 @@ func TestResourceErrorsApplication(t *testing.T) {
 
 func TestResourceErrorsApplicationCustomBucket(t *testing.T) {
 	var data atomic.Value
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
 		t.Log("Received " + r.Method + " " + r.RequestURI)
 
 		if r.Header.Get("Authorization") != "Bearer foo" {
@@ -257,7 +257,7 @@ func TestResourceErrorsApplicationCustomBucket(t *testing.T) {
 		default:
 			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
 		}
-	}))
+	})
 	defer server.Close()
 
 	var name = "Test Errors Application with Custom Bucket"
@@ -340,7 +340,7 @@ func TestResourceErrorsApplicationCustomBucket(t *testing.T) {
 
 func TestResourceErrorsApplicationCustomBucketKeepData(t *testing.T) {
 	var data atomic.Value
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
 		t.Log("Received " + r.Method + " " + r.RequestURI)
 
 		if r.Header.Get("Authorization") != "Bearer foo" {
@@ -405,7 +405,7 @@ func TestResourceErrorsApplicationCustomBucketKeepData(t *testing.T) {
 		default:
 			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
 		}
-	}))
+	})
 	defer server.Close()
 
 	var name = "Test Errors Application with Custom Bucket Keep Data"
@@ -455,7 +455,7 @@ func TestResourceErrorsApplicationCustomBucketKeepData(t *testing.T) {
 
 func TestResourceErrorsApplicationCustomBucketRemovalValidation(t *testing.T) {
 	var data atomic.Value
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
 		t.Log("Received " + r.Method + " " + r.RequestURI)
 
 		if r.Header.Get("Authorization") != "Bearer foo" {
@@ -520,7 +520,7 @@ func TestResourceErrorsApplicationCustomBucketRemovalValidation(t *testing.T) {
 		default:
 			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
 		}
-	}))
+	})
 	defer server.Close()
 
 	var name = "Test Errors Application Custom Bucket Removal"
@@ -576,7 +576,7 @@ func TestResourceErrorsApplicationCustomBucketRemovalValidation(t *testing.T) {
 
 func TestResourceErrorsApplicationGithubRepository(t *testing.T) {
 	var data atomic.Value
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
 		t.Log("Received " + r.Method + " " + r.RequestURI)
 
 		if r.Header.Get("Authorization") != "Bearer foo" {
@@ -633,7 +633,7 @@ func TestResourceErrorsApplicationGithubRepository(t *testing.T) {
 		default:
 			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
 		}
-	}))
+	})
 	defer server.Close()
 
 	var name = "Test Errors Application GitHub Repository"
@@ -687,7 +687,7 @@ func TestResourceErrorsApplicationGithubRepository(t *testing.T) {
 
 func TestResourceErrorsApplicationGitlabRepository(t *testing.T) {
 	var data atomic.Value
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
 		t.Log("Received " + r.Method + " " + r.RequestURI)
 
 		if r.Header.Get("Authorization") != "Bearer foo" {
@@ -744,7 +744,7 @@ func TestResourceErrorsApplicationGitlabRepository(t *testing.T) {
 		default:
 			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
 		}
-	}))
+	})
 	defer server.Close()
 
 	var name = "Test Errors Application GitLab Repository"
@@ -834,7 +834,7 @@ func TestResourceErrorsApplicationRepositoryMutuallyExclusive(t *testing.T) {
 
 func TestResourceErrorsApplicationCodeMapping(t *testing.T) {
 	var data atomic.Value
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
 		t.Log("Received " + r.Method + " " + r.RequestURI)
 
 		if r.Header.Get("Authorization") != "Bearer foo" {
@@ -891,7 +891,7 @@ func TestResourceErrorsApplicationCodeMapping(t *testing.T) {
 		default:
 			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
 		}
-	}))
+	})
 	defer server.Close()
 
 	var name = "Test Errors Application Code Mapping"
@@ -949,7 +949,7 @@ func TestResourceErrorsApplicationCodeMapping(t *testing.T) {
 
 func TestResourceErrorsApplicationVrlTransformations(t *testing.T) {
 	var data atomic.Value
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
 		t.Log("Received " + r.Method + " " + r.RequestURI)
 
 		if r.Header.Get("Authorization") != "Bearer foo" {
@@ -999,7 +999,7 @@ func TestResourceErrorsApplicationVrlTransformations(t *testing.T) {
 		default:
 			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
 		}
-	}))
+	})
 	defer server.Close()
 
 	name := "Test Errors Application"
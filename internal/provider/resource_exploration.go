@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"net/url"
 
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -146,9 +148,10 @@ var explorationSchema = map[string]*schema.Schema{
 					ValidateFunc: validation.StringInSlice([]string{"sql_expression", "tail_query", "static_text", "pql_expression", "query_builder", "funnel_query"}, false),
 				},
 				"sql_query": {
-					Description: "The SQL query string. Required when query_type is 'sql_expression'.",
-					Type:        schema.TypeString,
-					Optional:    true,
+					Description:      "The SQL query string. Required when query_type is 'sql_expression'.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validateSQLQuery,
 				},
 				"where_condition": {
 					Description: "The WHERE condition for filtering. Required when query_type is 'tail_query'.",
@@ -199,9 +202,10 @@ var explorationSchema = map[string]*schema.Schema{
 					Elem:        &schema.Schema{Type: schema.TypeString},
 				},
 				"sql_definition": {
-					Description: "SQL definition for 'select_with_sql' or 'multi_select_with_sql' type variables.",
-					Type:        schema.TypeString,
-					Optional:    true,
+					Description:      "SQL definition for 'select_with_sql' or 'multi_select_with_sql' type variables.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validateSQLExpression,
 				},
 			},
 		},
@@ -218,59 +222,23 @@ func newExplorationResource() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Description:   "This resource allows you to create, modify, and delete Explorations in Better Stack Telemetry. Explorations are interactive charts with queries and variables.",
-		CustomizeDiff: validateTeamNameNotChanged,
+		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateSQLVariables("query.*.sql_query", "variable.*.sql_definition")),
 		Schema:        explorationSchema,
 	}
 }
 
-// API structs
-type explorationChart struct {
-	ChartType   *string                `json:"chart_type,omitempty"`
-	Name        *string                `json:"name,omitempty"`
-	Description *string                `json:"description,omitempty"`
-	Settings    map[string]interface{} `json:"settings,omitempty"`
-}
-
-type explorationQuery struct {
-	ID             *int    `json:"id,omitempty"`
-	Name           *string `json:"name,omitempty"`
-	QueryType      *string `json:"query_type,omitempty"`
-	SQLQuery       *string `json:"sql_query,omitempty"`
-	WhereCondition *string `json:"where_condition,omitempty"`
-	StaticText     *string `json:"static_text,omitempty"`
-	SourceVariable *string `json:"source_variable,omitempty"`
-}
-
-type explorationVariable struct {
-	Name          *string  `json:"name,omitempty"`
-	VariableType  *string  `json:"variable_type,omitempty"`
-	Values        []string `json:"values,omitempty"`
-	DefaultValues []string `json:"default_values,omitempty"`
-	SQLDefinition *string  `json:"sql_definition,omitempty"`
-}
-
-type exploration struct {
-	Name               *string               `json:"name,omitempty"`
-	DateRangeFrom      *string               `json:"date_range_from,omitempty"`
-	DateRangeTo        *string               `json:"date_range_to,omitempty"`
-	ExplorationGroupID *int                  `json:"exploration_group_id,omitempty"`
-	Chart              *explorationChart     `json:"chart,omitempty"`
-	Queries            []explorationQuery    `json:"queries,omitempty"`
-	Variables          []explorationVariable `json:"variables,omitempty"`
-	CreatedAt          *string               `json:"created_at,omitempty"`
-	UpdatedAt          *string               `json:"updated_at,omitempty"`
-	TeamName           *string               `json:"team_name,omitempty"`
-}
+type (
+	explorationChart    = betterstack.ExplorationChart
+	explorationQuery    = betterstack.ExplorationQuery
+	explorationVariable = betterstack.ExplorationVariable
+	exploration         = betterstack.Exploration
+)
 
-type explorationHTTPResponse struct {
-	Data struct {
-		ID         string      `json:"id"`
-		Attributes exploration `json:"attributes"`
-	} `json:"data"`
-}
+type explorationHTTPResponse = betterstack.Response[exploration]
 
 func explorationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	in := loadExploration(d)
+	applyDefaultTeamName(d, meta, &in.TeamName)
 
 	var out explorationHTTPResponse
 	if err := resourceCreate(ctx, meta, "/api/v2/explorations", &in, &out); err != nil {
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"reflect"
 
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -52,19 +53,9 @@ func newExplorationGroupResource() *schema.Resource {
 	}
 }
 
-type explorationGroup struct {
-	Name      *string `json:"name,omitempty"`
-	CreatedAt *string `json:"created_at,omitempty"`
-	UpdatedAt *string `json:"updated_at,omitempty"`
-	TeamName  *string `json:"team_name,omitempty"`
-}
+type explorationGroup = betterstack.ExplorationGroup
 
-type explorationGroupHTTPResponse struct {
-	Data struct {
-		ID         string           `json:"id"`
-		Attributes explorationGroup `json:"attributes"`
-	} `json:"data"`
-}
+type explorationGroupHTTPResponse = betterstack.Response[explorationGroup]
 
 func explorationGroupRef(in *explorationGroup) []struct {
 	k string
@@ -87,6 +78,7 @@ func explorationGroupCreate(ctx context.Context, d *schema.ResourceData, meta in
 	}
 
 	load(d, "team_name", &in.TeamName)
+	applyDefaultTeamName(d, meta, &in.TeamName)
 
 	var out explorationGroupHTTPResponse
 	if err := resourceCreate(ctx, meta, "/api/v2/exploration-groups", &in, &out); err != nil {
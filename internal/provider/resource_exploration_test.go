@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -531,6 +532,29 @@ func TestResourceExplorationWithVariables(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateId:     "2",
 			},
+			// Referencing a variable without a variable block fails at plan time.
+			{
+				Config: `
+				provider "logtail" {
+					api_token = "foo"
+				}
+
+				resource "logtail_exploration" "this" {
+					name = "Exploration With Variables"
+
+					chart {
+						chart_type = "line_chart"
+					}
+
+					query {
+						query_type = "sql_expression"
+						sql_query  = "SELECT {{time}} AS time, count(*) AS value FROM {{source}} WHERE level = {{severity}} GROUP BY time"
+					}
+				}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`query.0.sql_query: line 1, column 74: \{\{severity\}\} is not a declared variable`),
+			},
 		},
 	})
 }
@@ -2,14 +2,12 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"reflect"
 	"strings"
 
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -34,9 +32,10 @@ var metricSchema = map[string]*schema.Schema{
 		Required:    true,
 	},
 	"sql_expression": {
-		Description: "The SQL expression used to extract the metric value.",
-		Type:        schema.TypeString,
-		Required:    true,
+		Description:      "The SQL expression used to extract the metric value.",
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateDiagFunc: validateSQLExpression,
 	},
 	"aggregations": {
 		Description: "The list of aggregations to perform on the metric. Optional: omit it (or set it to an empty list) to create a Label (a group-by dimension) instead of a Metric.",
@@ -68,33 +67,9 @@ func newMetricResource() *schema.Resource {
 	}
 }
 
-type metric struct {
-	SourceID      *string   `json:"source_id,omitempty"`
-	Name          *string   `json:"name,omitempty"`
-	SQLExpression *string   `json:"sql_expression,omitempty"`
-	Aggregations  *[]string `json:"aggregations,omitempty"`
-	Type          *string   `json:"type,omitempty"`
-}
+type metric = betterstack.Metric
 
-type metricHTTPResponse struct {
-	Data struct {
-		ID         string `json:"id"`
-		Attributes metric `json:"attributes"`
-	} `json:"data"`
-}
-
-type metricPageHTTPResponse struct {
-	Data []struct {
-		ID         string `json:"id"`
-		Attributes metric `json:"attributes"`
-	} `json:"data"`
-	Pagination struct {
-		First string `json:"first"`
-		Last  string `json:"last"`
-		Prev  string `json:"prev"`
-		Next  string `json:"next"`
-	} `json:"pagination"`
-}
+type metricHTTPResponse = betterstack.Response[metric]
 
 func metricRef(in *metric) []struct {
 	k string
@@ -173,52 +148,25 @@ func metricLookup(ctx context.Context, d *schema.ResourceData, meta interface{})
 	id := d.Id()
 	d.SetId("")
 	sourceId := d.Get("source_id").(string)
-	fetch := func(page int) (*metricPageHTTPResponse, error) {
-		res, err := meta.(*client).Get(ctx, fmt.Sprintf("/api/v2/sources/%s/metrics?page=%d", url.PathEscape(sourceId), page))
-		if err != nil {
-			return nil, err
-		}
-		defer func() {
-			// Keep-Alive.
-			_, _ = io.Copy(io.Discard, res.Body)
-			_ = res.Body.Close()
-		}()
-		body, err := io.ReadAll(res.Body)
-		if res.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-		}
-		if err != nil {
-			return nil, err
-		}
-		var tr metricPageHTTPResponse
-		return &tr, json.Unmarshal(body, &tr)
-	}
 	// The resource always has an ID here (create, update, import), so match on it -
 	// names repeat and are unknown right after an import. The data source has no ID
 	// and looks up by name.
 	name := d.Get("name").(string)
-	page := 1
-	for {
-		res, err := fetch(page)
+	for e, err := range meta.(*client).Metrics(sourceId).List(ctx) {
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		for _, e := range res.Data {
-			if (id != "" && e.ID == id) || (id == "" && *e.Attributes.Name == name) {
-				if d.Id() != "" {
-					return diag.Errorf("duplicate")
-				}
-				d.SetId(e.ID)
-				if derr := metricCopyAttrs(d, &e.Attributes); derr != nil {
-					return derr
-				}
+		if (id != "" && e.ID == id) || (id == "" && *e.Attributes.Name == name) {
+			if d.Id() != "" {
+				return diag.Errorf("duplicate")
+			}
+			d.SetId(e.ID)
+			if derr := metricCopyAttrs(d, &e.Attributes); derr != nil {
+				return derr
 			}
-		}
-		page++
-		if res.Pagination.Next == "" {
-			return nil
 		}
 	}
+	return nil
 }
 
 func metricCopyAttrs(d *schema.ResourceData, in *metric) diag.Diagnostics {
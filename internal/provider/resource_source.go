@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -104,26 +105,11 @@ var sourceSchema = map[string]*schema.Schema{
 		Computed:    true,
 	},
 	"platform": {
-		Description: "The platform of this source. This value can be set only when you're creating a new source. You can't update this value later. Valid values are:\n    - `" + strings.Join(platformTypes, "`\n    - `") + "`",
-		Type:        schema.TypeString,
-		Required:    true,
-		ForceNew:    true,
-		ValidateDiagFunc: func(v interface{}, path cty.Path) diag.Diagnostics {
-			s := v.(string)
-			for _, platformType := range platformTypes {
-				if s == platformType {
-					return nil
-				}
-			}
-			return diag.Diagnostics{
-				diag.Diagnostic{
-					AttributePath: path,
-					Severity:      diag.Error,
-					Summary:       `Invalid "platform"`,
-					Detail:        fmt.Sprintf("Expected one of %v", platformTypes),
-				},
-			}
-		},
+		Description:  "The platform of this source. This value can be set only when you're creating a new source. You can't update this value later. Valid values are the platforms of the `logtail_platforms` data source. When the platform catalog can't be read, these are accepted:\n    - `" + strings.Join(platformTypes, "`\n    - `") + "`",
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringIsNotEmpty,
 	},
 	"ingesting_host": {
 		Description: "The host where the logs or metrics should be sent. See [documentation](https://betterstack.com/docs/logs/start/) for your specific source platform for details.",
@@ -199,10 +185,26 @@ var sourceSchema = map[string]*schema.Schema{
 		Optional:    true,
 	},
 	"scrape_request_basic_auth_password": {
-		Description: "Basic auth password for scraping.",
-		Type:        schema.TypeString,
-		Optional:    true,
-		Sensitive:   true,
+		Description:   "Basic auth password for scraping.",
+		Type:          schema.TypeString,
+		Optional:      true,
+		Sensitive:     true,
+		ConflictsWith: []string{"scrape_request_basic_auth_password_wo"},
+	},
+	"scrape_request_basic_auth_password_wo": {
+		Description:   "Basic auth password for scraping as a write-only attribute (requires Terraform 1.11 or later), it is sent to the API but never stored in the state. Requires `scrape_request_basic_auth_password_wo_version`.",
+		Type:          schema.TypeString,
+		Optional:      true,
+		Sensitive:     true,
+		WriteOnly:     true,
+		ConflictsWith: []string{"scrape_request_basic_auth_password"},
+		RequiredWith:  []string{"scrape_request_basic_auth_password_wo_version"},
+	},
+	"scrape_request_basic_auth_password_wo_version": {
+		Description:  "Version of `scrape_request_basic_auth_password_wo`. Change it to send an updated password to the API.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		RequiredWith: []string{"scrape_request_basic_auth_password_wo"},
 	},
 	"skip_ssl_verify": {
 		Description: "Should the scraper skip SSL certificate verification? Enable for endpoints with self-signed or invalid certificates.",
@@ -270,11 +272,21 @@ var sourceSchema = map[string]*schema.Schema{
 					ValidateFunc: validation.StringIsNotEmpty,
 				},
 				"secret_access_key": {
-					Description:  "Secret access key",
+					Description:  "Secret access key. Exactly one of `secret_access_key` or `secret_access_key_wo` must be set.",
 					Type:         schema.TypeString,
-					Required:     true,
+					Optional:     true,
+					Sensitive:    true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					ExactlyOneOf: []string{"custom_bucket.0.secret_access_key", "custom_bucket.0.secret_access_key_wo"},
+				},
+				"secret_access_key_wo": {
+					Description:  "Secret access key as a write-only attribute (requires Terraform 1.11 or later), it is sent to the API when the source is created but never stored in the state. As `custom_bucket` can't be changed after creation, there is no version attribute to rotate it.",
+					Type:         schema.TypeString,
+					Optional:     true,
 					Sensitive:    true,
+					WriteOnly:    true,
 					ValidateFunc: validation.StringIsNotEmpty,
+					ExactlyOneOf: []string{"custom_bucket.0.secret_access_key", "custom_bucket.0.secret_access_key_wo"},
 				},
 				"keep_data_after_retention": {
 					Description: "Whether we should keep data in the bucket after the retention period.",
@@ -291,6 +303,7 @@ var sourceSchema = map[string]*schema.Schema{
 		Optional:         true,
 		Computed:         true,
 		DiffSuppressFunc: suppressEquivalentVRL,
+		ValidateDiagFunc: validateVRL,
 	},
 	"vrl_transformation_spans": {
 		Description:      "VRL transformation applied to traces (spans) on Better Stack's servers during ingestion. Leave unset to keep the transformation unmanaged; set to an empty string to remove it. Read more about [VRL transformations](https://betterstack.com/docs/logs/using-logtail/transforming-ingested-data/logs-vrl/).",
@@ -298,6 +311,7 @@ var sourceSchema = map[string]*schema.Schema{
 		Optional:         true,
 		Computed:         true,
 		DiffSuppressFunc: suppressEquivalentVRL,
+		ValidateDiagFunc: validateVRL,
 	},
 	"blocked_metrics": {
 		Description: "Metric names to mark as spam (one entry per metric). Listed metrics are rejected during ingestion and not billed.",
@@ -359,57 +373,22 @@ func newSourceResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateSource, customizeDiffVRL("vrl_transformation_logs"), customizeDiffVRL("vrl_transformation_spans")),
-		Description:   "This resource allows you to create, modify, and delete your Sources. For more information about the Sources API check https://betterstack.com/docs/logs/api/list-all-existing-sources/",
-		Schema:        sourceSchema,
+		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateSource, validatePlatform(platformTypes, sourcePlatformKinds), customizeDiffVRL("vrl_transformation_logs"), customizeDiffVRL("vrl_transformation_spans")),
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validation.PreferWriteOnlyAttribute(cty.GetAttrPath("scrape_request_basic_auth_password"), cty.GetAttrPath("scrape_request_basic_auth_password_wo")),
+			validation.PreferWriteOnlyAttribute(customBucketAttrPath("secret_access_key"), customBucketAttrPath("secret_access_key_wo")),
+		},
+		Description: "This resource allows you to create, modify, and delete your Sources. For more information about the Sources API check https://betterstack.com/docs/logs/api/list-all-existing-sources/",
+		Schema:      sourceSchema,
 	}
 }
 
-type sourceCustomBucket struct {
-	Name                   *string `json:"name,omitempty"`
-	Endpoint               *string `json:"endpoint,omitempty"`
-	AccessKeyID            *string `json:"access_key_id,omitempty"`
-	SecretAccessKey        *string `json:"secret_access_key,omitempty"`
-	KeepDataAfterRetention *bool   `json:"keep_data_after_retention,omitempty"`
-}
+type (
+	sourceCustomBucket = betterstack.SourceCustomBucket
+	source             = betterstack.Source
+)
 
-type source struct {
-	Name                           *string                   `json:"name,omitempty"`
-	Token                          *string                   `json:"token,omitempty"`
-	TeamId                         *StringOrInt              `json:"team_id,omitempty"`
-	TableName                      *string                   `json:"table_name,omitempty"`
-	Platform                       *string                   `json:"platform,omitempty"`
-	AWSAutoSubLogGroups            *bool                     `json:"aws_auto_sub_log_groups,omitempty"`
-	IngestingHost                  *string                   `json:"ingesting_host,omitempty"`
-	IngestingPaused                *bool                     `json:"ingesting_paused,omitempty"`
-	LogsRetention                  *int                      `json:"logs_retention,omitempty"`
-	MetricsRetention               *int                      `json:"metrics_retention,omitempty"`
-	LiveTailPattern                *string                   `json:"live_tail_pattern,omitempty"`
-	CreatedAt                      *string                   `json:"created_at,omitempty"`
-	UpdatedAt                      *string                   `json:"updated_at,omitempty"`
-	TeamName                       *string                   `json:"team_name,omitempty"`
-	ScrapeURLs                     *[]string                 `json:"scrape_urls,omitempty"`
-	ScrapeFrequencySecs            *int                      `json:"scrape_frequency_secs,omitempty"`
-	ScrapeRequestHeaders           *[]map[string]interface{} `json:"scrape_request_headers,omitempty"`
-	ScrapeRequestBasicAuthUser     *string                   `json:"scrape_request_basic_auth_user,omitempty"`
-	ScrapeRequestBasicAuthPassword *string                   `json:"scrape_request_basic_auth_password,omitempty"`
-	SkipSSLVerify                  *bool                     `json:"skip_ssl_verify,omitempty"`
-	DataRegion                     *string                   `json:"data_region,omitempty"`
-	SourceGroupID                  *int                      `json:"source_group_id,omitempty"`
-	CustomBucket                   *sourceCustomBucket       `json:"custom_bucket,omitempty"`
-	VrlTransformationLogs          *string                   `json:"vrl_transformation_logs,omitempty"`
-	VrlTransformationSpans         *string                   `json:"vrl_transformation_spans,omitempty"`
-	BlockedMetrics                 *[]string                 `json:"blocked_metrics,omitempty"`
-	CodeMappingStackRoot           *string                   `json:"code_mapping_stack_root,omitempty"`
-	CodeMappingSourceRoot          *string                   `json:"code_mapping_source_root,omitempty"`
-}
-
-type sourceHTTPResponse struct {
-	Data struct {
-		ID         string `json:"id"`
-		Attributes source `json:"attributes"`
-	} `json:"data"`
-}
+type sourceHTTPResponse = betterstack.Response[source]
 
 func sourceRef(in *source) []struct {
 	k string
@@ -470,6 +449,10 @@ func sourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{})
 	}
 
 	load(d, "team_name", &in.TeamName)
+	applyDefaultTeamName(d, meta, &in.TeamName)
+	if wo := writeOnlyStringFromResourceData(d, cty.GetAttrPath("scrape_request_basic_auth_password_wo")); wo != nil {
+		in.ScrapeRequestBasicAuthPassword = wo
+	}
 
 	if customBucketData, ok := d.GetOk("custom_bucket"); ok {
 		customBucketList := customBucketData.([]interface{})
@@ -478,7 +461,7 @@ func sourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{})
 			in.CustomBucket = &sourceCustomBucket{
 				Endpoint:               stringPtr(customBucketMap["endpoint"].(string)),
 				AccessKeyID:            stringPtr(customBucketMap["access_key_id"].(string)),
-				SecretAccessKey:        stringPtr(customBucketMap["secret_access_key"].(string)),
+				SecretAccessKey:        customBucketSecretAccessKey(d, customBucketMap),
 				KeepDataAfterRetention: boolPtr(customBucketMap["keep_data_after_retention"].(bool)),
 			}
 			// name is passed along when set, but the API ignores it and stores the bucket
@@ -518,8 +501,9 @@ func sourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{})
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
 			Summary:  "Azure source needs a connected Azure tenant",
-			Detail: "Connecting the tenant requires the interactive Microsoft admin consent. " +
-				fmt.Sprintf("Complete it in this source's Ingest tab at https://telemetry.betterstack.com/team/%s/sources/%s/data-ingestion. ", d.Get("team_id").(string), d.Id()) +
+			Detail: "Add a logtail_source_azure_tenant resource with the tenant ID and the client ID / client secret of an app registration to connect the tenant, " +
+				fmt.Sprintf("or complete the interactive Microsoft admin consent in this source's Ingest tab at https://telemetry.betterstack.com/team/%s/sources/%s/data-ingestion. ", d.Get("team_id").(string), d.Id()) +
+				"See https://registry.terraform.io/providers/BetterStackHQ/logtail/latest/docs/guides/connect-azure-tenant for detailed guide. " +
 				"If you already connected it, you can ignore this.",
 		})
 	}
@@ -548,6 +532,9 @@ func sourceCopyAttrs(d *schema.ResourceData, in *source) diag.Diagnostics {
 			if err := SetStringOrIntResourceData(d, "team_id", in.TeamId); err != nil {
 				derr = append(derr, diag.FromErr(err)[0])
 			}
+		} else if _, ok := d.GetOkExists("scrape_request_basic_auth_password_wo_version"); ok && e.k == "scrape_request_basic_auth_password" {
+			// The password is managed by the write-only attribute, keep it out of the state.
+			continue
 		} else if err := d.Set(e.k, reflect.Indirect(reflect.ValueOf(e.v)).Interface()); err != nil {
 			derr = append(derr, diag.FromErr(err)[0])
 		}
@@ -632,6 +619,13 @@ func sourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	// Write-only values never show up in a diff, a new password is sent when its version changes.
+	if d.HasChange("scrape_request_basic_auth_password_wo_version") {
+		if wo := writeOnlyStringFromResourceData(d, cty.GetAttrPath("scrape_request_basic_auth_password_wo")); wo != nil {
+			in.ScrapeRequestBasicAuthPassword = wo
+		}
+	}
+
 	return resourceUpdate(ctx, meta, fmt.Sprintf("/api/v2/sources/%s", url.PathEscape(d.Id())), &in)
 }
 
@@ -696,7 +690,8 @@ func validateRequestHeader(header map[string]interface{}) error {
 // so without this a config change would either fail the apply or plan the same update forever.
 // Two exceptions stay allowed because state legitimately starts without the value: filling in
 // secret_access_key (the API never returns the secret, so a configuration adds it after
-// terraform import) and filling in a previously-empty name.
+// terraform import) and filling in a previously-empty name. Removing secret_access_key is
+// allowed too, it only drops the secret from state when moving to secret_access_key_wo.
 func validateCustomBucketChange(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
 	// Only validate for existing resources (not during creation)
 	if diff.Id() == "" || !diff.HasChange("custom_bucket") {
@@ -725,12 +720,30 @@ func validateCustomBucketChange(ctx context.Context, diff *schema.ResourceDiff,
 	}
 	for _, k := range []string{"name", "secret_access_key"} {
 		if oldVal, newVal := oldMap[k], newMap[k]; oldVal != "" && !reflect.DeepEqual(oldVal, newVal) {
+			if k == "secret_access_key" && newVal == "" {
+				continue
+			}
 			return fmt.Errorf("custom_bucket.%s cannot be changed once set - recreate the resource to use a different bucket configuration", k)
 		}
 	}
 	return nil
 }
 
+// customBucketAttrPath returns the path of a custom_bucket attribute in any element of
+// the block, as expected by validation.PreferWriteOnlyAttribute.
+func customBucketAttrPath(attr string) cty.Path {
+	return cty.GetAttrPath("custom_bucket").Index(cty.UnknownVal(cty.Number)).GetAttr(attr)
+}
+
+// customBucketSecretAccessKey returns the custom_bucket secret to send to the API, read from
+// secret_access_key_wo in the raw config when set, otherwise from secret_access_key.
+func customBucketSecretAccessKey(d *schema.ResourceData, customBucket map[string]interface{}) *string {
+	if wo := writeOnlyStringFromResourceData(d, cty.GetAttrPath("custom_bucket").IndexInt(0).GetAttr("secret_access_key_wo")); wo != nil {
+		return wo
+	}
+	return stringPtr(customBucket["secret_access_key"].(string))
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
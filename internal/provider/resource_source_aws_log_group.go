@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -47,20 +48,9 @@ var sourceAWSLogGroupSchema = map[string]*schema.Schema{
 	},
 }
 
-type sourceAWSLogGroup struct {
-	Region     string `json:"region"`
-	Name       string `json:"name"`
-	Subscribed bool   `json:"subscribed"`
-	CreatedAt  string `json:"created_at,omitempty"`
-	UpdatedAt  string `json:"updated_at,omitempty"`
-}
+type sourceAWSLogGroup = betterstack.SourceAWSLogGroup
 
-type sourceAWSLogGroupHTTPResponse struct {
-	Data struct {
-		ID         string            `json:"id"`
-		Attributes sourceAWSLogGroup `json:"attributes"`
-	} `json:"data"`
-}
+type sourceAWSLogGroupHTTPResponse = betterstack.Response[sourceAWSLogGroup]
 
 func newSourceAWSLogGroupResource() *schema.Resource {
 	return &schema.Resource{
@@ -92,7 +82,8 @@ func sourceAWSLogGroupCreate(ctx context.Context, d *schema.ResourceData, meta i
 		Subscribed: d.Get("subscribed").(bool),
 	}
 	var out sourceAWSLogGroupHTTPResponse
-	if derr := resourceCreate(ctx, meta, sourceAWSLogGroupCollectionPath(d), &in, &out); derr != nil {
+	// Log-group names are unique per region only.
+	if derr := resourceCreate(betterstack.WithCreateKeys(ctx, "region", "name"), meta, sourceAWSLogGroupCollectionPath(d), &in, &out); derr != nil {
 		return derr
 	}
 
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var sourceAWSLogGroupsSchema = map[string]*schema.Schema{
+	"id": {
+		Description: "The ID of the source.",
+		Type:        schema.TypeString,
+		Optional:    false,
+		Computed:    true,
+	},
+	"source_id": {
+		Description: "The ID of the AWS `logtail_source` whose log-group subscription overrides this resource manages.",
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+	},
+	"log_group": {
+		Description: "The explicit log-group subscription overrides of the source. Overrides of the source not listed here are deleted, returning those groups to the source's automatic behavior.",
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"region": {
+					Description:  "The AWS region containing the CloudWatch log group.",
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				"name": {
+					Description:  "The CloudWatch log-group name.",
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				"subscribed": {
+					Description: "Whether Better Stack should subscribe this log group. Defaults to true.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+				},
+			},
+		},
+	},
+}
+
+func newSourceAWSLogGroupsResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: sourceAWSLogGroupsCreate,
+		ReadContext:   sourceAWSLogGroupsRead,
+		UpdateContext: sourceAWSLogGroupsUpdate,
+		DeleteContext: sourceAWSLogGroupsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: validateSourceAWSLogGroups,
+		Description: "Authoritatively manages all explicit CloudWatch log-group subscription overrides of an AWS source. " +
+			"Overrides created outside of this resource are deleted on the next apply, so don't combine it with `logtail_source_aws_log_group` for the same source. " +
+			"Destroying it deletes all overrides, returning the groups to the source's automatic behavior.",
+		Schema: sourceAWSLogGroupsSchema,
+	}
+}
+
+// sourceAWSLogGroupKey identifies an override, a source has at most one per region and name.
+type sourceAWSLogGroupKey struct {
+	region, name string
+}
+
+func (k sourceAWSLogGroupKey) String() string {
+	return fmt.Sprintf("%s in %s", k.name, k.region)
+}
+
+// validateSourceAWSLogGroups rejects two log_group blocks for the same region and name, which the
+// set only tells apart by subscribed.
+func validateSourceAWSLogGroups(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	seen := map[sourceAWSLogGroupKey]bool{}
+	for _, group := range diff.Get("log_group").(*schema.Set).List() {
+		group := group.(map[string]interface{})
+		key := sourceAWSLogGroupKey{region: group["region"].(string), name: group["name"].(string)}
+		if key.region == "" || key.name == "" {
+			continue // Unknown until apply.
+		}
+		if seen[key] {
+			return fmt.Errorf("log group %s is listed more than once", key)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+func sourceAWSLogGroupsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sourceID := d.Get("source_id").(string)
+	if derr, ok := reconcileSourceAWSLogGroups(ctx, meta, sourceID, d.Get("log_group").(*schema.Set).List()); derr != nil {
+		return derr
+	} else if !ok {
+		return diag.Errorf("source %s not found", sourceID)
+	}
+	d.SetId(sourceID)
+	return sourceAWSLogGroupsRead(ctx, d, meta)
+}
+
+func sourceAWSLogGroupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var groups []interface{}
+	for item, err := range meta.(*client).SourceAWSLogGroups(d.Id()).List(ctx) {
+		if betterstack.IsNotFound(err) {
+			d.SetId("") // Source gone -> overrides gone.
+			return nil
+		} else if err != nil {
+			return diag.FromErr(err)
+		}
+		groups = append(groups, map[string]interface{}{
+			"region":     item.Attributes.Region,
+			"name":       item.Attributes.Name,
+			"subscribed": item.Attributes.Subscribed,
+		})
+	}
+	if err := d.Set("log_group", groups); err != nil {
+		return diag.FromErr(err)
+	}
+	return diag.FromErr(d.Set("source_id", d.Id()))
+}
+
+func sourceAWSLogGroupsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if derr, ok := reconcileSourceAWSLogGroups(ctx, meta, d.Id(), d.Get("log_group").(*schema.Set).List()); derr != nil {
+		return derr
+	} else if !ok {
+		return diag.Errorf("source %s not found", d.Id())
+	}
+	return sourceAWSLogGroupsRead(ctx, d, meta)
+}
+
+func sourceAWSLogGroupsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// A deleted source took its overrides with it, like in Read.
+	if derr, _ := reconcileSourceAWSLogGroups(ctx, meta, d.Id(), nil); derr != nil {
+		return derr
+	}
+	d.SetId("")
+	return nil
+}
+
+// reconcileSourceAWSLogGroups makes the overrides of a source match groups: it creates the missing
+// overrides, updates the ones whose subscribed differs and deletes all others. The overrides are
+// listed from the API rather than taken from the state, so overrides created elsewhere are pruned too.
+// ok is false if the source doesn't exist, nothing is changed then.
+func reconcileSourceAWSLogGroups(ctx context.Context, meta interface{}, sourceID string, groups []interface{}) (derr diag.Diagnostics, ok bool) {
+	endpoint := meta.(*client).SourceAWSLogGroups(sourceID)
+	// Log-group names are unique per region only.
+	createCtx := betterstack.WithCreateKeys(ctx, "region", "name")
+	existing := map[sourceAWSLogGroupKey]betterstack.Item[sourceAWSLogGroup]{}
+	for item, err := range endpoint.List(ctx) {
+		if betterstack.IsNotFound(err) {
+			return nil, false
+		} else if err != nil {
+			return diag.FromErr(err), false
+		}
+		existing[sourceAWSLogGroupKey{region: item.Attributes.Region, name: item.Attributes.Name}] = item
+	}
+
+	for _, group := range groups {
+		group := group.(map[string]interface{})
+		in := sourceAWSLogGroup{
+			Region:     group["region"].(string),
+			Name:       group["name"].(string),
+			Subscribed: group["subscribed"].(bool),
+		}
+		key := sourceAWSLogGroupKey{region: in.Region, name: in.Name}
+		item, found := existing[key]
+		delete(existing, key)
+		if !found {
+			var out sourceAWSLogGroupHTTPResponse
+			if derr := resourceCreate(createCtx, meta, endpoint.Path(), &in, &out); derr != nil {
+				return derr, false
+			}
+		} else if item.Attributes.Subscribed != in.Subscribed {
+			req := struct {
+				Subscribed bool `json:"subscribed"`
+			}{Subscribed: in.Subscribed}
+			if derr := resourceUpdate(ctx, meta, endpoint.ItemPath(item.ID), &req); derr != nil {
+				return derr, false
+			}
+		}
+	}
+
+	for _, item := range existing {
+		if derr := resourceDelete(ctx, meta, endpoint.ItemPath(item.ID)); derr != nil {
+			return derr, false
+		}
+	}
+	return nil, true
+}
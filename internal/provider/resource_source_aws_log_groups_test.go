@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/betterstackhq/terraform-provider-logtail/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceSourceAWSLogGroups(t *testing.T) {
+	api := fakeapi.New(t)
+	sourceID := api.Seed("/api/v2/sources", map[string]interface{}{"name": "AWS", "platform": "aws"})
+	collectionPath := "/api/v2/sources/" + sourceID + "/aws-log-group-subscriptions"
+	unchanged := api.Seed(collectionPath, map[string]interface{}{"region": "us-east-1", "name": "/aws/lambda/api", "subscribed": true})
+	toggled := api.Seed(collectionPath, map[string]interface{}{"region": "us-east-1", "name": "/aws/lambda/noisy", "subscribed": true})
+	unmanaged := api.Seed(collectionPath, map[string]interface{}{"region": "eu-west-1", "name": "/aws/lambda/legacy", "subscribed": true})
+	p := configureTestProvider(t, api, map[string]interface{}{})
+	r := p.ResourcesMap["logtail_source_aws_log_groups"]
+	ctx := context.Background()
+
+	state, diags := applyTestResource(t, p, "logtail_source_aws_log_groups", map[string]interface{}{
+		"source_id": sourceID,
+		"log_group": []interface{}{
+			map[string]interface{}{"region": "us-east-1", "name": "/aws/lambda/api"},
+			map[string]interface{}{"region": "us-east-1", "name": "/aws/lambda/noisy", "subscribed": false},
+			map[string]interface{}{"region": "us-east-1", "name": "/aws/lambda/worker"},
+		},
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if state.ID != sourceID || state.Attributes["log_group.#"] != "3" {
+		t.Errorf("got state %v", state)
+	}
+
+	var mutations []string
+	for _, req := range api.Requests() {
+		if !strings.HasPrefix(req, "GET ") {
+			mutations = append(mutations, req)
+		}
+	}
+	sort.Strings(mutations)
+	want := []string{
+		"DELETE " + collectionPath + "/" + unmanaged,
+		"PATCH " + collectionPath + "/" + toggled,
+		"POST " + collectionPath,
+	}
+	if !reflect.DeepEqual(mutations, want) {
+		t.Errorf("got requests %v, want %v", mutations, want)
+	}
+	if attrs := api.Attributes(collectionPath + "/" + toggled); attrs["subscribed"] != false {
+		t.Errorf("got toggled override %v", attrs)
+	}
+
+	// Importing by source ID reads all overrides of the source.
+	imported := r.TestResourceData()
+	imported.SetId(sourceID)
+	if diags := r.ReadContext(ctx, imported, p.Meta()); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if imported.Get("source_id") != sourceID || imported.Get("log_group.#") != 3 {
+		t.Errorf("got imported state %v", imported.State())
+	}
+
+	if diags := r.DeleteContext(ctx, imported, p.Meta()); diags.HasError() {
+		t.Fatal(diags)
+	}
+	for _, id := range []string{unchanged, toggled} {
+		if attrs := api.Attributes(collectionPath + "/" + id); attrs != nil {
+			t.Errorf("got override %s %v after destroy, want it deleted", id, attrs)
+		}
+	}
+}
+
+func TestResourceSourceAWSLogGroupsDeleteAfterSource(t *testing.T) {
+	api := fakeapi.New(t)
+	sourceID := api.Seed("/api/v2/sources", map[string]interface{}{"name": "AWS", "platform": "aws"})
+	p := configureTestProvider(t, api, map[string]interface{}{})
+	r := p.ResourcesMap["logtail_source_aws_log_groups"]
+	ctx := context.Background()
+
+	d := r.TestResourceData()
+	d.SetId(sourceID)
+	if err := p.Meta().(*client).Sources().Delete(ctx, sourceID); err != nil {
+		t.Fatal(err)
+	}
+	// The overrides are gone with the source, like Read treats it.
+	if diags := r.DeleteContext(ctx, d, p.Meta()); diags.HasError() {
+		t.Fatalf("got diagnostics %v, want the overrides of a deleted source deleted", diags)
+	}
+}
+
+func TestResourceSourceAWSLogGroupsLifecycle(t *testing.T) {
+	api := fakeapi.New(t)
+
+	config := func(logGroups string) string {
+		return fmt.Sprintf(`
+		provider "logtail" {
+			api_token = "foo"
+		}
+
+		resource "logtail_source" "this" {
+			name     = "AWS"
+			platform = "aws"
+		}
+
+		resource "logtail_source_aws_log_groups" "this" {
+			source_id = logtail_source.this.id
+			%s
+		}
+		`, logGroups)
+	}
+	// subscribed checks the subscribed flag of every override of the source in the API.
+	subscribed := func(want map[string]bool) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			sourceID := s.RootModule().Resources["logtail_source_aws_log_groups.this"].Primary.ID
+			got := make(map[string]bool)
+			for _, path := range api.Paths() {
+				if strings.HasPrefix(path, "/api/v2/sources/"+sourceID+"/aws-log-group-subscriptions/") {
+					attrs := api.Attributes(path)
+					got[fmt.Sprintf("%s %s", attrs["region"], attrs["name"])] = attrs["subscribed"].(bool)
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				return fmt.Errorf("got overrides %v, want %v", got, want)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"logtail": func() (*schema.Provider, error) {
+				return New(WithURL(api.URL)), nil
+			},
+		},
+		Steps: []resource.TestStep{
+			// Step 1: create - subscribed defaults to true inside the set, the next plan is empty.
+			{
+				Config: config(`
+				log_group {
+					region = "us-east-1"
+					name   = "/aws/lambda/api"
+				}
+
+				log_group {
+					region     = "us-east-1"
+					name       = "/aws/lambda/noisy"
+					subscribed = false
+				}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("logtail_source_aws_log_groups.this", "log_group.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("logtail_source_aws_log_groups.this", "log_group.*", map[string]string{
+						"region":     "us-east-1",
+						"name":       "/aws/lambda/api",
+						"subscribed": "true",
+					}),
+					subscribed(map[string]bool{"us-east-1 /aws/lambda/api": true, "us-east-1 /aws/lambda/noisy": false}),
+				),
+			},
+			// Step 2: import by source ID.
+			{
+				ResourceName:      "logtail_source_aws_log_groups.this",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Step 3: the same name in another region is another override, removed ones are deleted.
+			{
+				Config: config(`
+				log_group {
+					region = "us-east-1"
+					name   = "/aws/lambda/api"
+				}
+
+				log_group {
+					region = "eu-west-1"
+					name   = "/aws/lambda/api"
+				}
+				`),
+				Check: subscribed(map[string]bool{"us-east-1 /aws/lambda/api": true, "eu-west-1 /aws/lambda/api": true}),
+			},
+		},
+	})
+}
+
+func TestResourceSourceAWSLogGroupsRejectsDuplicates(t *testing.T) {
+	p := New()
+	r := p.ResourcesMap["logtail_source_aws_log_groups"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"source_id": "1",
+		"log_group": []interface{}{
+			map[string]interface{}{"region": "us-east-1", "name": "/aws/lambda/api"},
+			map[string]interface{}{"region": "us-east-1", "name": "/aws/lambda/api", "subscribed": false},
+		},
+	})
+	if _, err := r.Diff(context.Background(), nil, config, nil); err == nil || !strings.Contains(err.Error(), "listed more than once") {
+		t.Errorf("got error %v, want the duplicate log group rejected", err)
+	}
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var sourceAzureTenantSchema = map[string]*schema.Schema{
+	"source_id": {
+		Description: "The ID of the `logtail_source` (with `platform = \"azure\"`) to link the Azure tenant to. Changing this forces a new resource, re-running the tenant connect against the new source.",
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+	},
+	"azure_account_id": {
+		Description:  "The ID of an already-connected Azure tenant to link this source to. Provide this instead of `azure_tenant_id`/`azure_client_id` to reuse a tenant you've already connected. Write-only: the API does not return it, so it isn't refreshed from state.",
+		Type:         schema.TypeString,
+		Optional:     true,
+		AtLeastOneOf: []string{"azure_account_id", "azure_tenant_id"},
+	},
+	"azure_tenant_id": {
+		Description:  "The Microsoft Entra tenant (directory) ID to connect. Provide together with `azure_client_id` and a client secret. Write-only: the API does not return it, so it isn't refreshed from state.",
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.IsUUID,
+		RequiredWith: []string{"azure_client_id"},
+	},
+	"azure_client_id": {
+		Description:  "The application (client) ID of the app registration Better Stack signs in with. The app needs the Reader and Monitoring Contributor roles on the selected subscriptions. Provide together with `azure_tenant_id`. Write-only: the API does not return it, so it isn't refreshed from state.",
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.IsUUID,
+		RequiredWith: []string{"azure_tenant_id"},
+	},
+	"azure_client_secret": {
+		Description:   "A client secret of the app registration. Write-only: the API does not return it, so it isn't refreshed from state.",
+		Type:          schema.TypeString,
+		Optional:      true,
+		Sensitive:     true,
+		ConflictsWith: []string{"azure_client_secret_wo", "azure_account_id"},
+		RequiredWith:  []string{"azure_client_id"},
+	},
+	"azure_client_secret_wo": {
+		Description:   "Write-only alternative to `azure_client_secret`, never stored in the Terraform plan or state. Requires Terraform 1.11 or later. Increment `azure_client_secret_wo_version` to send a changed secret.",
+		Type:          schema.TypeString,
+		Optional:      true,
+		Sensitive:     true,
+		WriteOnly:     true,
+		ConflictsWith: []string{"azure_client_secret", "azure_account_id"},
+		RequiredWith:  []string{"azure_client_id", "azure_client_secret_wo_version"},
+	},
+	"azure_client_secret_wo_version": {
+		Description:  "Version of `azure_client_secret_wo`. Change it to send a rotated client secret.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		RequiredWith: []string{"azure_client_secret_wo"},
+	},
+	"subscription_ids": {
+		Description: "The IDs of the Azure subscriptions Better Stack collects logs and metrics from. When omitted, all subscriptions the app registration can read are collected and the current API setting is preserved.",
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Computed:    true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.IsUUID,
+		},
+	},
+	"auto_subscribe_diagnostic_settings": {
+		Description: "Whether Better Stack automatically creates diagnostic settings forwarding the logs of new resources in the selected subscriptions. When omitted, the current API setting is preserved.",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Computed:    true,
+	},
+}
+
+func newSourceAzureTenantResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: sourceAzureTenantCreate,
+		ReadContext:   sourceAzureTenantRead,
+		UpdateContext: sourceAzureTenantUpdate,
+		DeleteContext: sourceAzureTenantDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: validateSourceAzureTenant,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validation.PreferWriteOnlyAttribute(cty.GetAttrPath("azure_client_secret"), cty.GetAttrPath("azure_client_secret_wo")),
+		},
+		Description: "Links a Microsoft Entra tenant to an `azure` platform `logtail_source` with the tenant ID and the " +
+			"client ID / client secret of an app registration (or by reusing an already-connected tenant), instead of " +
+			"the interactive admin consent in the source's Ingest tab.\n\n" +
+			"The credentials are write-only - the API never returns them, so they aren't refreshed into " +
+			"Terraform state. Destroying this resource only removes it from state; the Azure tenant stays " +
+			"linked to the source until the source itself is destroyed.",
+		Schema: sourceAzureTenantSchema,
+	}
+}
+
+// validateSourceAzureTenant requires a client secret with azure_tenant_id. The write-only secret is
+// null in the plan, so it can't be expressed with RequiredWith.
+func validateSourceAzureTenant(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	if !diff.NewValueKnown("azure_tenant_id") || diff.Get("azure_tenant_id").(string) == "" {
+		return nil
+	}
+	if !diff.NewValueKnown("azure_client_secret") || diff.Get("azure_client_secret").(string) != "" || writeOnlyConfigured(diff, cty.GetAttrPath("azure_client_secret_wo")) {
+		return nil
+	}
+	return fmt.Errorf("azure_tenant_id requires azure_client_secret or azure_client_secret_wo")
+}
+
+// sourceAzureTenantPayload is the PATCH body for tenant linkage and its collection settings.
+// Credentials are write-only; the subscriptions and the auto-subscribe setting are returned by
+// the Sources API.
+type sourceAzureTenantPayload struct {
+	AzureAccountID                 *string   `json:"azure_account_id,omitempty"`
+	AzureTenantID                  *string   `json:"azure_tenant_id,omitempty"`
+	AzureClientID                  *string   `json:"azure_client_id,omitempty"`
+	AzureClientSecret              *string   `json:"azure_client_secret,omitempty"`
+	AzureSubscriptionIDs           *[]string `json:"azure_subscription_ids,omitempty"`
+	AzureAutoSubDiagnosticSettings *bool     `json:"azure_auto_sub_diagnostic_settings,omitempty"`
+}
+
+// patchSourceAzureTenant sends the configured tenant settings. The full credential set is
+// always sent because the connect manager validates the tenant ID, client ID and secret together.
+func patchSourceAzureTenant(ctx context.Context, d *schema.ResourceData, meta interface{}, sourceID string) diag.Diagnostics {
+	in := sourceAzureTenantPayload{
+		AzureAccountID:                 stringFromResourceData(d, "azure_account_id"),
+		AzureTenantID:                  stringFromResourceData(d, "azure_tenant_id"),
+		AzureClientID:                  stringFromResourceData(d, "azure_client_id"),
+		AzureClientSecret:              stringFromResourceData(d, "azure_client_secret"),
+		AzureAutoSubDiagnosticSettings: boolFromResourceData(d, "auto_subscribe_diagnostic_settings"),
+	}
+	if v := writeOnlyStringFromResourceData(d, cty.GetAttrPath("azure_client_secret_wo")); v != nil {
+		in.AzureClientSecret = v
+	}
+	if v, ok := d.GetOk("subscription_ids"); ok {
+		var ids []string
+		for _, id := range v.(*schema.Set).List() {
+			ids = append(ids, id.(string))
+		}
+		in.AzureSubscriptionIDs = &ids
+	}
+	return resourceUpdate(ctx, meta, fmt.Sprintf("/api/v2/sources/%s", url.PathEscape(sourceID)), &in)
+}
+
+func sourceAzureTenantCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sourceID := d.Get("source_id").(string)
+	if derr := patchSourceAzureTenant(ctx, d, meta, sourceID); derr != nil {
+		return derr
+	}
+	d.SetId(sourceID)
+	return sourceAzureTenantRead(ctx, d, meta)
+}
+
+func sourceAzureTenantRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var out sourceHTTPResponse
+	if err, ok := resourceReadWithBaseURL(ctx, meta, meta.(*client).TelemetryBaseURL(), fmt.Sprintf("/api/v2/sources/%s", url.PathEscape(d.Id())), &out); err != nil {
+		return err
+	} else if !ok {
+		d.SetId("") // Source gone -> linkage gone.
+		return nil
+	}
+	// Credentials are write-only; refresh the readable settings and confirm the source still exists.
+	if out.Data.Attributes.AzureSubscriptionIDs != nil {
+		if err := d.Set("subscription_ids", *out.Data.Attributes.AzureSubscriptionIDs); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if out.Data.Attributes.AzureAutoSubDiagnosticSettings != nil {
+		if err := d.Set("auto_subscribe_diagnostic_settings", *out.Data.Attributes.AzureAutoSubDiagnosticSettings); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return diag.FromErr(d.Set("source_id", d.Id()))
+}
+
+func sourceAzureTenantUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if derr := patchSourceAzureTenant(ctx, d, meta, d.Get("source_id").(string)); derr != nil {
+		return derr
+	}
+	return sourceAzureTenantRead(ctx, d, meta)
+}
+
+func sourceAzureTenantDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The Sources API has no disconnect endpoint: PATCHing blank Azure params is a server-side
+	// no-op (the connect manager never unlinks a tenant). Deleting this resource therefore only
+	// drops it from Terraform state; the Azure tenant stays linked to the source until the source
+	// itself is destroyed.
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/betterstackhq/terraform-provider-logtail/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceSourceAzureTenant(t *testing.T) {
+	api := fakeapi.New(t)
+	sourceID := api.Seed("/api/v2/sources", map[string]interface{}{"name": "Azure", "platform": "azure"})
+	p := configureTestProvider(t, api, map[string]interface{}{})
+	r := p.ResourcesMap["logtail_source_azure_tenant"]
+	ctx := context.Background()
+
+	const (
+		tenantID       = "11111111-1111-1111-1111-111111111111"
+		clientID       = "22222222-2222-2222-2222-222222222222"
+		subscriptionA  = "33333333-3333-3333-3333-333333333333"
+		subscriptionB  = "44444444-4444-4444-4444-444444444444"
+		sourcePath     = "/api/v2/sources/"
+		expectedSecret = "s3cret"
+	)
+
+	state, diags := applyTestResource(t, p, "logtail_source_azure_tenant", map[string]interface{}{
+		"source_id":                          sourceID,
+		"azure_tenant_id":                    tenantID,
+		"azure_client_id":                    clientID,
+		"azure_client_secret":                expectedSecret,
+		"subscription_ids":                   []interface{}{subscriptionA, subscriptionB},
+		"auto_subscribe_diagnostic_settings": true,
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if state.ID != sourceID {
+		t.Errorf("got ID %q, want the source ID %s", state.ID, sourceID)
+	}
+
+	attrs := api.Attributes(sourcePath + sourceID)
+	for k, want := range map[string]interface{}{
+		"azure_tenant_id":                    tenantID,
+		"azure_client_id":                    clientID,
+		"azure_client_secret":                expectedSecret,
+		"azure_auto_sub_diagnostic_settings": true,
+	} {
+		if attrs[k] != want {
+			t.Errorf("got %s %v, want %v", k, attrs[k], want)
+		}
+	}
+	b, _ := json.Marshal(attrs["azure_subscription_ids"])
+	var gotIDs []string
+	_ = json.Unmarshal(b, &gotIDs)
+	sort.Strings(gotIDs)
+	if !reflect.DeepEqual(gotIDs, []string{subscriptionA, subscriptionB}) {
+		t.Errorf("got azure_subscription_ids %v", attrs["azure_subscription_ids"])
+	}
+
+	// Importing by source ID refreshes the readable settings only.
+	imported := r.TestResourceData()
+	imported.SetId(sourceID)
+	if diags := r.ReadContext(ctx, imported, p.Meta()); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if imported.Get("source_id") != sourceID || imported.Get("auto_subscribe_diagnostic_settings") != true || imported.Get("subscription_ids.#") != 2 || imported.Get("azure_client_secret") != "" {
+		t.Errorf("got imported state %v", imported.State())
+	}
+
+	if diags := r.DeleteContext(ctx, imported, p.Meta()); diags.HasError() {
+		t.Fatal(diags)
+	}
+	for _, req := range api.Requests() {
+		if strings.HasPrefix(req, "DELETE ") {
+			t.Errorf("got request %s, destroying the link must leave the source alone", req)
+		}
+	}
+}
+
+func TestResourceSourceAzureTenantLifecycle(t *testing.T) {
+	api := fakeapi.New(t)
+
+	config := func(secret string, version int, subscriptionIDs string) string {
+		return fmt.Sprintf(`
+		provider "logtail" {
+			api_token = "foo"
+		}
+
+		resource "logtail_source" "this" {
+			name     = "Azure"
+			platform = "azure"
+		}
+
+		resource "logtail_source_azure_tenant" "this" {
+			source_id                          = logtail_source.this.id
+			azure_tenant_id                    = "11111111-1111-1111-1111-111111111111"
+			azure_client_id                    = "22222222-2222-2222-2222-222222222222"
+			azure_client_secret_wo             = %q
+			azure_client_secret_wo_version     = %d
+			subscription_ids                   = [%s]
+			auto_subscribe_diagnostic_settings = true
+		}
+		`, secret, version, subscriptionIDs)
+	}
+	// checkSecret checks the client secret the API received, it's never stored in the state.
+	checkSecret := func(want string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			rs := s.RootModule().Resources["logtail_source_azure_tenant.this"]
+			if _, ok := rs.Primary.Attributes["azure_client_secret_wo"]; ok {
+				return fmt.Errorf("azure_client_secret_wo stored in the state")
+			}
+			if got := api.Attributes("/api/v2/sources/" + rs.Primary.ID)["azure_client_secret"]; got != want {
+				return fmt.Errorf("got client secret %v, want %s", got, want)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"logtail": func() (*schema.Provider, error) {
+				return New(WithURL(api.URL)), nil
+			},
+		},
+		Steps: []resource.TestStep{
+			// Step 1: link the tenant with the write-only secret, the next plan is empty.
+			{
+				Config: config("s3cret", 1, `"33333333-3333-3333-3333-333333333333"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("logtail_source_azure_tenant.this", "id", "logtail_source.this", "id"),
+					resource.TestCheckResourceAttr("logtail_source_azure_tenant.this", "subscription_ids.#", "1"),
+					resource.TestCheckResourceAttr("logtail_source_azure_tenant.this", "azure_client_secret_wo_version", "1"),
+					checkSecret("s3cret"),
+				),
+			},
+			// Step 2: add a subscription.
+			{
+				Config: config("s3cret", 1, `"33333333-3333-3333-3333-333333333333", "44444444-4444-4444-4444-444444444444"`),
+				Check:  resource.TestCheckResourceAttr("logtail_source_azure_tenant.this", "subscription_ids.#", "2"),
+			},
+			// Step 3: import by source ID - the credentials can't be read back.
+			{
+				ResourceName:            "logtail_source_azure_tenant.this",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"azure_tenant_id", "azure_client_id", "azure_client_secret_wo_version"},
+			},
+			// Step 4: bump the version - the rotated secret is sent.
+			{
+				Config: config("r0tated", 2, `"33333333-3333-3333-3333-333333333333", "44444444-4444-4444-4444-444444444444"`),
+				Check:  checkSecret("r0tated"),
+			},
+		},
+	})
+}
+
+func TestResourceSourceAzureTenantRequiresClientSecret(t *testing.T) {
+	p := New()
+	r := p.ResourcesMap["logtail_source_azure_tenant"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"source_id":       "1",
+		"azure_tenant_id": "11111111-1111-1111-1111-111111111111",
+		"azure_client_id": "22222222-2222-2222-2222-222222222222",
+	})
+	if _, err := r.Diff(context.Background(), nil, config, nil); err == nil || !strings.Contains(err.Error(), "requires azure_client_secret or azure_client_secret_wo") {
+		t.Errorf("got error %v, want the client secret required", err)
+	}
+}
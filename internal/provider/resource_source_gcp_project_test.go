@@ -21,7 +21,7 @@ func TestResourceSourceGCPProject(t *testing.T) {
 	var lastPatchBody atomic.Value
 	var sourceDeletes int32
 
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
 		t.Log("Received " + r.Method + " " + r.RequestURI)
 
 		if r.Header.Get("Authorization") != "Bearer foo" {
@@ -77,7 +77,7 @@ func TestResourceSourceGCPProject(t *testing.T) {
 		default:
 			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
 		}
-	}))
+	})
 	defer server.Close()
 
 	patchBodyContains := func(needle string) resource.TestCheckFunc {
@@ -176,7 +176,7 @@ func TestResourceSourceGCPProject(t *testing.T) {
 func TestResourceSourceGCPProjectExistingAccount(t *testing.T) {
 	var data atomic.Value
 	var lastPatchBody atomic.Value
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
 		if r.Header.Get("Authorization") != "Bearer foo" {
 			t.Fatal("Not authorized: " + r.Header.Get("Authorization"))
 		}
@@ -210,7 +210,7 @@ func TestResourceSourceGCPProjectExistingAccount(t *testing.T) {
 		default:
 			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
 		}
-	}))
+	})
 	defer server.Close()
 
 	resource.Test(t, resource.TestCase{
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"reflect"
 
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -57,20 +58,9 @@ func newSourceGroupResource() *schema.Resource {
 	}
 }
 
-type sourceGroup struct {
-	Name      *string `json:"name,omitempty"`
-	SortIndex *int    `json:"sort_index,omitempty"`
-	CreatedAt *string `json:"created_at,omitempty"`
-	UpdatedAt *string `json:"updated_at,omitempty"`
-	TeamName  *string `json:"team_name,omitempty"`
-}
+type sourceGroup = betterstack.SourceGroup
 
-type sourceGroupHTTPResponse struct {
-	Data struct {
-		ID         string      `json:"id"`
-		Attributes sourceGroup `json:"attributes"`
-	} `json:"data"`
-}
+type sourceGroupHTTPResponse = betterstack.Response[sourceGroup]
 
 func sourceGroupRef(in *sourceGroup) []struct {
 	k string
@@ -94,6 +84,7 @@ func sourceGroupCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 	}
 
 	load(d, "team_name", &in.TeamName)
+	applyDefaultTeamName(d, meta, &in.TeamName)
 
 	var out sourceGroupHTTPResponse
 	if err := resourceCreate(ctx, meta, "/api/v1/source-groups", &in, &out); err != nil {
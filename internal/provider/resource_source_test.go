@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/http/httptest"
 	"regexp"
 	"strings"
 	"sync/atomic"
@@ -21,7 +20,7 @@ func TestResourceSource(t *testing.T) {
 	var data atomic.Value
 	// Track the last request body for assertions
 	var lastRequestBody atomic.Value
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
 		t.Log("Received " + r.Method + " " + r.RequestURI)
 
 		if r.Header.Get("Authorization") != "Bearer foo" {
@@ -90,7 +89,7 @@ func TestResourceSource(t *testing.T) {
 		default:
 			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
 		}
-	}))
+	})
 	defer server.Close()
 
 	var name = "Test Source"
@@ -854,7 +853,7 @@ func TestResourceSource(t *testing.T) {
 				PlanOnly:    true,
 				ExpectError: regexp.MustCompile(`The argument "access_key_id" is required`),
 			},
-			// Step 4 - custom_bucket missing secret_access_key (schema validation)
+			// Step 4 - custom_bucket missing both secret_access_key and secret_access_key_wo (schema validation)
 			{
 				Config: fmt.Sprintf(`
 				provider "logtail" {
@@ -872,7 +871,7 @@ func TestResourceSource(t *testing.T) {
 				}
 				`, name, platform),
 				PlanOnly:    true,
-				ExpectError: regexp.MustCompile(`The argument "secret_access_key" is required`),
+				ExpectError: regexp.MustCompile(`one of\s+` + "`" + `custom_bucket\.0\.secret_access_key,custom_bucket\.0\.secret_access_key_wo` + "`" + `\s+must\s+be specified`),
 			},
 		},
 	})
@@ -1060,7 +1059,7 @@ func TestResourceSource(t *testing.T) {
 func TestResourceSourcePerTypeVrl(t *testing.T) {
 	var data atomic.Value
 	seededDefault := ".parsed = parse_json!(.message)\n."
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
 		if r.Header.Get("Authorization") != "Bearer foo" {
 			t.Fatal("Not authorized: " + r.Header.Get("Authorization"))
 		}
@@ -1116,7 +1115,7 @@ func TestResourceSourcePerTypeVrl(t *testing.T) {
 		default:
 			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
 		}
-	}))
+	})
 	defer server.Close()
 
 	name := "Test Source"
@@ -1145,6 +1144,22 @@ func TestResourceSourcePerTypeVrl(t *testing.T) {
 				`, name, platform),
 				ExpectError: regexp.MustCompile(`(?s)Unsupported argument|not expected here`),
 			},
+			// Syntax errors are reported at plan time, without calling the API.
+			{
+				Config: fmt.Sprintf(`
+				provider "logtail" {
+					api_token = "foo"
+				}
+
+				resource "logtail_source" "this" {
+					name                     = "%s"
+					platform                 = "%s"
+					vrl_transformation_spans = "if .name == \"GET /health\" {\n  del(.)\n"
+				}
+				`, name, platform),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Syntax error at line 1, column 27: unclosed "{"`),
+			},
 			// An unmanaged transformation is readable from state: the platform-seeded default
 			// lands in the computed attribute without being config-managed.
 			{
@@ -1245,7 +1260,7 @@ func TestResourceSourceBlockedMetrics(t *testing.T) {
 		}
 		return body
 	}
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
 		if r.Header.Get("Authorization") != "Bearer foo" {
 			t.Fatal("Not authorized: " + r.Header.Get("Authorization"))
 		}
@@ -1293,7 +1308,7 @@ func TestResourceSourceBlockedMetrics(t *testing.T) {
 		default:
 			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
 		}
-	}))
+	})
 	defer server.Close()
 
 	name := "Test Source"
@@ -1364,7 +1379,7 @@ func TestResourceSourceBlockedMetrics(t *testing.T) {
 
 func TestResourceSourceCodeMapping(t *testing.T) {
 	var data atomic.Value
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
 		t.Log("Received " + r.Method + " " + r.RequestURI)
 
 		if r.Header.Get("Authorization") != "Bearer foo" {
@@ -1417,7 +1432,7 @@ func TestResourceSourceCodeMapping(t *testing.T) {
 		default:
 			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
 		}
-	}))
+	})
 	defer server.Close()
 
 	var name = "Test Source Code Mapping"
@@ -1477,7 +1492,7 @@ func TestResourceSourceCodeMapping(t *testing.T) {
 // the cluster name ("eu-nbg-2"), not the region identifier given at creation, so the plan stays empty.
 func TestResourceSourceImportDataRegion(t *testing.T) {
 	var data atomic.Value
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
 		if r.Header.Get("Authorization") != "Bearer foo" {
 			t.Fatal("Not authorized: " + r.Header.Get("Authorization"))
 		}
@@ -1508,7 +1523,7 @@ func TestResourceSourceImportDataRegion(t *testing.T) {
 		default:
 			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
 		}
-	}))
+	})
 	defer server.Close()
 
 	resource.Test(t, resource.TestCase{
@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var sourceTokenRotationSchema = map[string]*schema.Schema{
+	"id": {
+		Description: "The ID of this token rotation.",
+		Type:        schema.TypeString,
+		Optional:    false,
+		Computed:    true,
+	},
+	"source_id": {
+		Description: "The ID of the `logtail_source` whose token is rotated.",
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+	},
+	"keepers": {
+		Description: "Arbitrary values that rotate the token again when changed, e.g. an incident number after a token leaked.",
+		Type:        schema.TypeMap,
+		Optional:    true,
+		ForceNew:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	},
+	"rotate_after": {
+		Description:      "Rotate the token again once this duration has passed since the last rotation, e.g. `720h`. The rotation is due when the resource is refreshed after that, the next apply rotates the token.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validateDuration,
+	},
+	"grace_period": {
+		Description:      "How long the previous token keeps being accepted after a rotation, e.g. `24h`, so agents can be redeployed with the new token without dropping data. By default the previous token is revoked right away. Changing it applies to the next rotation.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validateDuration,
+	},
+	"token": {
+		Description: "The new token of the source.",
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+	},
+	"previous_token": {
+		Description: "The token the rotation replaced.",
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+	},
+	"previous_token_expires_at": {
+		Description: "The time until which the previous token is accepted, see `grace_period`.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	},
+	"rotated_at": {
+		Description: "The time when the token was rotated.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	},
+}
+
+func newSourceTokenRotationResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: sourceTokenRotationCreate,
+		ReadContext:   sourceTokenRotationRead,
+		UpdateContext: sourceTokenRotationUpdate,
+		DeleteContext: sourceTokenRotationDelete,
+		Description: "Rotates the ingestion token of a source without re-creating it, keeping its data and `table_name`. " +
+			"The token is rotated when this resource is created, i.e. on the first apply and whenever `source_id` or `keepers` change or `rotate_after` has passed. " +
+			"Reference `token` of this resource instead of `logtail_source.token` to deploy the new token. Destroying this resource doesn't change the token.",
+		Schema: sourceTokenRotationSchema,
+	}
+}
+
+// validateDuration accepts non-negative Go durations, e.g. "24h" or "90m".
+func validateDuration(v interface{}, path cty.Path) diag.Diagnostics {
+	d, err := time.ParseDuration(v.(string))
+	if err == nil && d < 0 {
+		err = fmt.Errorf("%q is negative", v)
+	}
+	if err != nil {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				AttributePath: path,
+				Severity:      diag.Error,
+				Summary:       "Invalid duration",
+				Detail:        fmt.Sprintf(`%v, expected a duration like "24h" or "90m"`, err),
+			},
+		}
+	}
+	return nil
+}
+
+func sourceTokenRotationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var in betterstack.SourceTokenRotation
+	if v, ok := d.GetOk("grace_period"); ok {
+		gracePeriod, _ := time.ParseDuration(v.(string))
+		seconds := int(gracePeriod.Seconds())
+		in.GracePeriod = &seconds
+	}
+	var out betterstack.Response[betterstack.SourceTokenRotation]
+	sourceID := d.Get("source_id").(string)
+	// Repeating a rotation whose response was lost would replace its token, which nobody has seen,
+	// while agents still send the original one: the grace period would only cover the lost token.
+	err := meta.(*client).Create(betterstack.WithoutCreateRetry(ctx), meta.(*client).TelemetryBaseURL(), meta.(*client).SourceTokenRotations(sourceID).Path(), &in, &out)
+	if betterstack.MayHaveCreated(err) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "The token may have been rotated",
+			Detail: fmt.Sprintf("Rotating the token of source %s failed, but the rotation may still have happened: %v\n\n"+
+				"It isn't retried, as another rotation would replace the new token before anyone saw it. "+
+				"Check the token of the source in Better Stack and whether agents still ingest before applying again.", sourceID, err),
+		}}
+	}
+	if err != nil {
+		return writeDiagnostics(err)
+	}
+
+	d.SetId(out.Data.ID)
+	attrs := out.Data.Attributes
+	for k, v := range map[string]*string{
+		"token":                     attrs.Token,
+		"previous_token":            attrs.PreviousToken,
+		"previous_token_expires_at": attrs.PreviousTokenExpiresAt,
+		"rotated_at":                attrs.CreatedAt,
+	} {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+// sourceTokenRotationRead only checks that the source still exists, a rotation can't be read back.
+// Once rotate_after has passed, the rotation is removed from the state to be created again.
+func sourceTokenRotationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var out betterstack.Response[betterstack.Source]
+	if derr, ok := resourceReadWithBaseURL(ctx, meta, meta.(*client).TelemetryBaseURL(), meta.(*client).Sources().ItemPath(d.Get("source_id").(string)), &out); derr != nil {
+		return derr
+	} else if !ok {
+		d.SetId("")
+		return nil
+	}
+
+	if due, ok := sourceTokenRotationDueAt(d); ok && !time.Now().Before(due) {
+		d.SetId("")
+	}
+	return nil
+}
+
+// sourceTokenRotationDueAt returns when rotate_after has passed since the rotation.
+func sourceTokenRotationDueAt(d *schema.ResourceData) (time.Time, bool) {
+	rotateAfter, err := time.ParseDuration(d.Get("rotate_after").(string))
+	if err != nil {
+		return time.Time{}, false
+	}
+	rotatedAt, err := time.Parse(time.RFC3339, d.Get("rotated_at").(string))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return rotatedAt.Add(rotateAfter), true
+}
+
+// sourceTokenRotationUpdate stores changes of rotate_after and grace_period, they take effect
+// with the next rotation.
+func sourceTokenRotationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+func sourceTokenRotationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/betterstackhq/terraform-provider-logtail/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceSourceTokenRotation(t *testing.T) {
+	api := fakeapi.New(t)
+	sourceID := api.Seed("/api/v2/sources", map[string]interface{}{"name": "Backend", "platform": "ubuntu", "token": "leaked"})
+	p := configureTestProvider(t, api, map[string]interface{}{})
+	r := p.ResourcesMap["logtail_source_token_rotation"]
+	ctx := context.Background()
+
+	d := r.TestResourceData()
+	for k, v := range map[string]interface{}{"source_id": sourceID, "grace_period": "24h", "rotate_after": "720h"} {
+		if err := d.Set(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if diags := r.CreateContext(ctx, d, p.Meta()); diags.HasError() {
+		t.Fatal(diags)
+	}
+	token := d.Get("token").(string)
+	if d.Id() == "" || token == "" || token == "leaked" || d.Get("previous_token") != "leaked" {
+		t.Errorf("got ID %q, token %q and previous_token %q", d.Id(), token, d.Get("previous_token"))
+	}
+	if got := api.Attributes("/api/v2/sources/" + sourceID)["token"]; got != token {
+		t.Errorf("got source token %v, want %s", got, token)
+	}
+	rotatedAt, err := time.Parse(time.RFC3339, d.Get("rotated_at").(string))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiresAt, err := time.Parse(time.RFC3339, d.Get("previous_token_expires_at").(string))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := expiresAt.Sub(rotatedAt); got != 24*time.Hour {
+		t.Errorf("got previous token valid for %s, want 24h", got)
+	}
+
+	if diags := r.ReadContext(ctx, d, p.Meta()); diags.HasError() || d.Id() == "" {
+		t.Fatalf("got diagnostics %v and ID %q, want the rotation kept", diags, d.Id())
+	}
+
+	// Once rotate_after has passed, the rotation is gone from the state and created again.
+	if err := d.Set("rotated_at", time.Now().Add(-721*time.Hour).UTC().Format(time.RFC3339)); err != nil {
+		t.Fatal(err)
+	}
+	if diags := r.ReadContext(ctx, d, p.Meta()); diags.HasError() || d.Id() != "" {
+		t.Fatalf("got diagnostics %v and ID %q, want the rotation due", diags, d.Id())
+	}
+
+	for _, req := range api.Requests() {
+		if req != "POST /api/v2/sources/"+sourceID+"/token-rotations" && req != "GET /api/v2/sources/"+sourceID {
+			t.Errorf("got unexpected request %s", req)
+		}
+	}
+}
+
+func TestResourceSourceTokenRotationLostResponse(t *testing.T) {
+	api := fakeapi.New(t)
+	sourceID := api.Seed("/api/v2/sources", map[string]interface{}{"name": "Backend", "platform": "ubuntu", "token": "original"})
+
+	// The first rotation happens, but its response is lost on the way back.
+	var posts int
+	handler := api.Config.Handler
+	api.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posts++
+			if posts == 1 {
+				handler.ServeHTTP(httptest.NewRecorder(), r)
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+		}
+		handler.ServeHTTP(w, r)
+	})
+
+	p := configureTestProvider(t, api, map[string]interface{}{})
+	r := p.ResourcesMap["logtail_source_token_rotation"]
+	d := r.TestResourceData()
+	if err := d.Set("source_id", sourceID); err != nil {
+		t.Fatal(err)
+	}
+	diags := r.CreateContext(context.Background(), d, p.Meta())
+	if !diags.HasError() || diags[0].Summary != "The token may have been rotated" || !strings.Contains(diags[0].Detail, "Check the token of the source") {
+		t.Fatalf("got diagnostics %v, want the user asked to check the token", diags)
+	}
+	if posts != 1 || d.Id() != "" {
+		t.Errorf("got %d rotations and ID %q, want a single rotation and no ID", posts, d.Id())
+	}
+	// The token of the rotation that happened is the one that can be found on the source.
+	if got := api.Attributes("/api/v2/sources/" + sourceID)["token"]; got == "original" {
+		t.Errorf("got source token %v, want the token rotated once", got)
+	}
+}
+
+func TestResourceSourceTokenRotationLifecycle(t *testing.T) {
+	api := fakeapi.New(t)
+
+	config := func(incident, rotateAfter string) string {
+		return fmt.Sprintf(`
+		provider "logtail" {
+			api_token = "foo"
+		}
+
+		resource "logtail_source" "this" {
+			name     = "Backend"
+			platform = "ubuntu"
+		}
+
+		resource "logtail_source_token_rotation" "this" {
+			source_id    = logtail_source.this.id
+			grace_period = "24h"
+			rotate_after = %q
+			keepers = {
+				incident = %q
+			}
+		}
+		`, rotateAfter, incident)
+	}
+
+	// token records the rotated token, checkRotated checks it changed since the previous check
+	// and is the token of the source in the API.
+	var token string
+	checkRotated := func(rotated bool) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			rs := s.RootModule().Resources["logtail_source_token_rotation.this"]
+			got := rs.Primary.Attributes["token"]
+			if rotated && (got == token || rs.Primary.Attributes["previous_token"] == got) {
+				return fmt.Errorf("got token %q and previous token %q, want a new token", got, rs.Primary.Attributes["previous_token"])
+			} else if !rotated && got != token {
+				return fmt.Errorf("got token %q, want %q kept", got, token)
+			}
+			source := api.Attributes("/api/v2/sources/" + rs.Primary.Attributes["source_id"])
+			if source["token"] != got {
+				return fmt.Errorf("got source token %v, want %s", source["token"], got)
+			}
+			token = got
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"logtail": func() (*schema.Provider, error) {
+				return New(WithURL(api.URL)), nil
+			},
+		},
+		Steps: []resource.TestStep{
+			// Step 1: create - the token is rotated, the next plan is empty. There's no import
+			// step, a rotation can't be read back from the API.
+			{
+				Config: config("1", "720h"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("logtail_source_token_rotation.this", "previous_token", "logtail_source.this", "token"),
+					resource.TestCheckResourceAttrSet("logtail_source_token_rotation.this", "previous_token_expires_at"),
+					checkRotated(true),
+				),
+			},
+			// Step 2: change keepers - the token is rotated again.
+			{
+				Config: config("2", "720h"),
+				Check:  checkRotated(true),
+			},
+			// Step 3: shorten rotate_after - it's stored without rotating, the rotation is then due.
+			{
+				Config:             config("2", "0s"),
+				Check:              checkRotated(false),
+				ExpectNonEmptyPlan: true,
+			},
+			// Step 4: the due rotation is planned again.
+			{
+				Config:             config("2", "0s"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Step 5: apply the due rotation - the token is rotated and the next plan is empty.
+			{
+				Config: config("2", "720h"),
+				Check:  checkRotated(true),
+			},
+		},
+	})
+}
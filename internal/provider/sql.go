@@ -0,0 +1,286 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Plan-time linting of ClickHouse SQL. Like the VRL validation this only catches syntax errors that
+// don't need the ClickHouse parser - unbalanced brackets, unterminated literals, comments and
+// {{variable}} placeholders, stray characters and multiple statements. Column and function names are
+// still checked by Better Stack on apply.
+
+// sqlDefaultVariables are created automatically for every dashboard and exploration.
+var sqlDefaultVariables = []string{"time", "start_time", "end_time", "source"}
+
+// sqlVariablePlaceholder matches a {{variable}} inside a string literal.
+var sqlVariablePlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// sqlVariableRef is a {{variable}} referenced by a query.
+type sqlVariableRef struct {
+	name   string
+	line   int
+	column int
+}
+
+// sqlToken is the position of a bracket or statement separator.
+type sqlToken struct {
+	r      rune
+	line   int
+	column int
+}
+
+var sqlClosers = map[rune]rune{'(': ')', '[': ']', '{': '}'}
+
+// scanSQL checks the syntax of a ClickHouse query (starting with SELECT or WITH) or, if query is
+// false, an expression. It returns the referenced variables, or a *syntaxError.
+func scanSQL(sql string, query bool) ([]sqlVariableRef, error) {
+	s := &textScanner{src: []rune(sql), line: 1, column: 1}
+	var refs []sqlVariableRef
+	var open []sqlToken
+	var statementEnd *sqlToken
+	first := true
+	for s.pos < len(s.src) {
+		r := s.peek(0)
+		line, column := s.line, s.column
+
+		switch {
+		case unicode.IsSpace(r):
+			s.advance()
+			continue
+		case r == '-' && s.peek(1) == '-' || r == '#':
+			for s.pos < len(s.src) && s.peek(0) != '\n' {
+				s.advance()
+			}
+			continue
+		case r == '/' && s.peek(1) == '*':
+			s.advance()
+			s.advance()
+			for s.pos < len(s.src) && !(s.peek(0) == '*' && s.peek(1) == '/') {
+				s.advance()
+			}
+			if s.pos >= len(s.src) {
+				return nil, s.errorf(line, column, "unterminated comment")
+			}
+			s.advance()
+			s.advance()
+			continue
+		}
+
+		if statementEnd != nil {
+			return nil, s.errorf(line, column, "unexpected input after \";\" at line %d, column %d, multiple statements are not supported", statementEnd.line, statementEnd.column)
+		}
+		if first && query && !isSQLQueryStart(s) {
+			return nil, s.errorf(line, column, "expected the query to start with SELECT or WITH")
+		}
+		first = false
+
+		switch {
+		case r == '\'' || r == '"' || r == '`':
+			start := s.pos
+			if err := s.sqlQuoted(); err != nil {
+				return nil, err
+			}
+			// Variables are substituted in string literals too, e.g. '%{{search}}%'.
+			literal := string(s.src[start:s.pos])
+			for _, m := range sqlVariablePlaceholder.FindAllStringSubmatchIndex(literal, -1) {
+				ref := sqlVariableRef{name: literal[m[2]:m[3]], line: line, column: column}
+				for _, r := range literal[:m[0]] {
+					if r == '\n' {
+						ref.line++
+						ref.column = 1
+					} else {
+						ref.column++
+					}
+				}
+				refs = append(refs, ref)
+			}
+		case r == '{' && s.peek(1) == '{':
+			name, err := s.sqlPlaceholder()
+			if err != nil {
+				return nil, err
+			}
+			refs = append(refs, sqlVariableRef{name: name, line: line, column: column})
+		case r == ';':
+			s.advance()
+			statementEnd = &sqlToken{r: r, line: line, column: column}
+		case sqlClosers[r] != 0:
+			s.advance()
+			open = append(open, sqlToken{r: r, line: line, column: column})
+		case r == ')' || r == ']' || r == '}':
+			s.advance()
+			if len(open) == 0 {
+				return nil, s.errorf(line, column, "unexpected %q without a matching opening bracket", r)
+			}
+			opener := open[len(open)-1]
+			if expected := sqlClosers[opener.r]; expected != r {
+				return nil, s.errorf(line, column, "unexpected %q, expected %q to close %q opened at line %d, column %d", r, expected, opener.r, opener.line, opener.column)
+			}
+			open = open[:len(open)-1]
+		case r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			for s.pos < len(s.src) && (s.peek(0) == '_' || s.peek(0) == '$' || unicode.IsLetter(s.peek(0)) || unicode.IsDigit(s.peek(0))) {
+				s.advance()
+			}
+		case strings.ContainsRune("+-*/%=<>!|&^~?:.,@", r):
+			s.advance()
+		default:
+			return nil, s.errorf(line, column, "unexpected character %q", r)
+		}
+	}
+
+	if len(open) > 0 {
+		opener := open[len(open)-1]
+		return nil, s.errorf(opener.line, opener.column, "unclosed %q, expected %q before the end of the query", opener.r, sqlClosers[opener.r])
+	}
+	return refs, nil
+}
+
+// isSQLQueryStart reports whether the scanner is at SELECT, WITH or an opening parenthesis.
+func isSQLQueryStart(s *textScanner) bool {
+	if s.peek(0) == '(' {
+		return true
+	}
+	end := s.pos
+	for end < len(s.src) && (s.src[end] == '_' || unicode.IsLetter(s.src[end])) {
+		end++
+	}
+	keyword := strings.ToUpper(string(s.src[s.pos:end]))
+	return keyword == "SELECT" || keyword == "WITH"
+}
+
+// sqlQuoted scans a string literal ('...'), or a quoted identifier ("..." or `...`). The quote is
+// escaped either with a backslash or by doubling it.
+func (s *textScanner) sqlQuoted() error {
+	line, column := s.line, s.column
+	quote := s.advance()
+	for s.pos < len(s.src) {
+		switch s.advance() {
+		case '\\':
+			if s.pos < len(s.src) {
+				s.advance()
+			}
+		case quote:
+			if s.peek(0) != quote {
+				return nil
+			}
+			s.advance()
+		}
+	}
+	if quote == '\'' {
+		return s.errorf(line, column, "unterminated string literal")
+	}
+	return s.errorf(line, column, "unterminated quoted identifier")
+}
+
+// sqlPlaceholder scans a {{variable}} placeholder and returns the variable name.
+func (s *textScanner) sqlPlaceholder() (string, error) {
+	line, column := s.line, s.column
+	s.advance()
+	s.advance()
+	for s.peek(0) == ' ' {
+		s.advance()
+	}
+	start := s.pos
+	for s.pos < len(s.src) && (s.peek(0) == '_' || unicode.IsLetter(s.peek(0)) || unicode.IsDigit(s.peek(0))) {
+		s.advance()
+	}
+	name := string(s.src[start:s.pos])
+	for s.peek(0) == ' ' {
+		s.advance()
+	}
+	if name == "" || unicode.IsDigit(rune(name[0])) || s.peek(0) != '}' || s.peek(1) != '}' {
+		return "", s.errorf(line, column, "invalid variable placeholder, expected {{variable_name}}")
+	}
+	s.advance()
+	s.advance()
+	return name, nil
+}
+
+// validateSQLQuery is the ValidateDiagFunc of attributes holding a full query.
+func validateSQLQuery(i interface{}, path cty.Path) diag.Diagnostics {
+	return validateSQL(i, path, true)
+}
+
+// validateSQLExpression is the ValidateDiagFunc of attributes holding an SQL expression or condition.
+func validateSQLExpression(i interface{}, path cty.Path) diag.Diagnostics {
+	return validateSQL(i, path, false)
+}
+
+func validateSQL(i interface{}, path cty.Path, query bool) diag.Diagnostics {
+	sql, ok := i.(string)
+	if !ok || strings.TrimSpace(sql) == "" {
+		return nil
+	}
+	if _, err := scanSQL(sql, query); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid SQL",
+			Detail:        fmt.Sprintf("Syntax error at %s.", err),
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
+
+// validateSQLVariables returns a CustomizeDiffFunc checking that the SQL attributes at keys only
+// reference variables declared in the `variable` blocks of the resource, or the default ones. A `*`
+// in a key stands for every item of a list, e.g. `query.*.sql_query`. Values that aren't known yet
+// are skipped, syntax errors are reported by the ValidateDiagFunc of the attribute.
+func validateSQLVariables(keys ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+		if !diff.NewValueKnown("variable") {
+			return nil
+		}
+		declared := make(map[string]bool)
+		for _, name := range sqlDefaultVariables {
+			declared[name] = true
+		}
+		for i := range diff.Get("variable").([]interface{}) {
+			key := fmt.Sprintf("variable.%d.name", i)
+			if !diff.NewValueKnown(key) {
+				return nil
+			}
+			declared[diff.Get(key).(string)] = true
+		}
+
+		for _, key := range expandSQLKeys(diff, keys) {
+			if !diff.NewValueKnown(key) {
+				continue
+			}
+			sql, _ := diff.Get(key).(string)
+			refs, err := scanSQL(sql, false)
+			if err != nil {
+				continue
+			}
+			for _, ref := range refs {
+				if !declared[ref.name] {
+					return fmt.Errorf("%s: line %d, column %d: {{%s}} is not a declared variable, add a variable block for it or use one of the default variables %s", key, ref.line, ref.column, ref.name, strings.Join(sqlDefaultVariables, ", "))
+				}
+			}
+		}
+		return nil
+	}
+}
+
+func expandSQLKeys(diff *schema.ResourceDiff, keys []string) []string {
+	var out []string
+	for _, key := range keys {
+		list, attr, ok := strings.Cut(key, ".*.")
+		if !ok {
+			out = append(out, key)
+			continue
+		}
+		items, _ := diff.Get(list).([]interface{})
+		for i := range items {
+			out = append(out, fmt.Sprintf("%s.%d.%s", list, i, attr))
+		}
+	}
+	return out
+}
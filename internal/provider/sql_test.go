@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestScanSQL(t *testing.T) {
+	valid := []struct {
+		sql  string
+		vars []string
+	}{
+		{"SELECT {{time}} AS time, count(*) AS value FROM {{source}} WHERE time BETWEEN {{start_time}} AND {{end_time}} GROUP BY time", []string{"time", "source", "start_time", "end_time"}},
+		{"WITH x AS (SELECT 1) SELECT * FROM x;", nil},
+		{"select {{ time }} from {{source}} -- {{commented_out}}\n/* {{also_commented}} */", []string{"time", "source"}},
+		{"SELECT 1 FROM {{source}} WHERE message ILIKE '%{{search}}%' AND \"quoted\"\"col\" = 'it''s \\' ok'", []string{"source", "search"}},
+		{"SELECT JSONExtractString(raw, 'level') AS series FROM {{source}} WHERE time > now() [[ AND series = {{level}} ]] GROUP BY series", []string{"source", "level"}},
+		{"(SELECT `col` FROM t WHERE x = {param:String}) # trailing comment", nil},
+	}
+	for _, tt := range valid {
+		refs, err := scanSQL(tt.sql, true)
+		if err != nil {
+			t.Errorf("scanSQL(%q): unexpected error %v", tt.sql, err)
+			continue
+		}
+		var names []string
+		for _, ref := range refs {
+			names = append(names, ref.name)
+		}
+		if strings.Join(names, ",") != strings.Join(tt.vars, ",") {
+			t.Errorf("scanSQL(%q): got variables %v, want %v", tt.sql, names, tt.vars)
+		}
+	}
+
+	if _, err := scanSQL("JSONExtract(json, 'duration_ms', 'Nullable(Float)')", false); err != nil {
+		t.Errorf("unexpected error for expression: %v", err)
+	}
+
+	invalid := []struct {
+		sql  string
+		want string
+	}{
+		{"SELECT count(* FROM logs", "line 1, column 13: unclosed '('"},
+		{"SELECT [1, 2) FROM logs", "line 1, column 13: unexpected ')', expected ']' to close '[' opened at line 1, column 8"},
+		{"SELECT 1)", "line 1, column 9: unexpected ')' without a matching opening bracket"},
+		{"SELECT 1\nFROM logs WHERE level = 'error", "line 2, column 25: unterminated string literal"},
+		{"SELECT \"level FROM logs", "line 1, column 8: unterminated quoted identifier"},
+		{"SELECT 1 /* comment", "line 1, column 10: unterminated comment"},
+		{"SELECT {{time} FROM logs", "line 1, column 8: invalid variable placeholder"},
+		{"SELECT {{}} FROM logs", "line 1, column 8: invalid variable placeholder"},
+		{"SELECT 1; DROP TABLE logs", "line 1, column 11: unexpected input after \";\" at line 1, column 9"},
+		{"SELECT 1 \\ 2", "line 1, column 10: unexpected character '\\\\'"},
+		{"-- comment\nSHOW TABLES", "line 2, column 1: expected the query to start with SELECT or WITH"},
+	}
+	for _, tt := range invalid {
+		_, err := scanSQL(tt.sql, true)
+		if err == nil {
+			t.Errorf("scanSQL(%q): expected an error", tt.sql)
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("scanSQL(%q): got %q, want prefix %q", tt.sql, err.Error(), tt.want)
+		}
+	}
+}
+
+func TestValidateSQLVariables(t *testing.T) {
+	r := newExplorationResource()
+	diff := func(config map[string]interface{}) error {
+		_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+		return err
+	}
+	query := func(sql string) []interface{} {
+		return []interface{}{map[string]interface{}{"query_type": "sql_expression", "sql_query": sql}}
+	}
+
+	if err := diff(map[string]interface{}{
+		"name":  "Exploration",
+		"query": query("SELECT {{time}} AS time FROM {{source}} WHERE level = {{level}}"),
+		"variable": []interface{}{
+			map[string]interface{}{"name": "level", "variable_type": "select_with_sql", "sql_definition": "JSONExtractString(raw, 'level')"},
+		},
+	}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := diff(map[string]interface{}{
+		"name":  "Exploration",
+		"query": query("SELECT {{time}} AS time FROM {{source}} WHERE level = {{level}}"),
+	})
+	if err == nil || !strings.Contains(err.Error(), "query.0.sql_query: line 1, column 55: {{level}} is not a declared variable") {
+		t.Errorf("expected an undeclared variable error, got %v", err)
+	}
+
+	// Variable definitions may only reference other declared variables too.
+	err = diff(map[string]interface{}{
+		"name":  "Exploration",
+		"query": query("SELECT 1"),
+		"variable": []interface{}{
+			map[string]interface{}{"name": "level", "variable_type": "select_with_sql", "sql_definition": "JSONExtractString(raw, '{{field}}')"},
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "variable.0.sql_definition: line 1, column 25: {{field}} is not a declared variable") {
+		t.Errorf("expected an undeclared variable error, got %v", err)
+	}
+}
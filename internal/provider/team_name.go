@@ -7,13 +7,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// dataSourceTeamNameDescription describes team_name in the data sources built from resource
+// schemas: they only look resources up, so default_team_name doesn't apply to them.
+const dataSourceTeamNameDescription = "Used to specify the team the resource should be created in when using global tokens. You can't update this value later."
+
 // teamNameSchema returns the schema for the team_name attribute shared by every resource that can
 // be created in a specific team using a global API token. The value is only used when the resource
 // is created; afterwards any change is suppressed (see DiffSuppressFunc) and changing it to a
 // different non-empty value is rejected (see validateTeamNameNotChanged).
 func teamNameSchema() *schema.Schema {
 	return &schema.Schema{
-		Description: "Used to specify the team the resource should be created in when using global tokens. You can't update this value later.",
+		Description: "Used to specify the team the resource should be created in when using global tokens. Defaults to the provider's `default_team_name`. You can't update this value later.",
 		Type:        schema.TypeString,
 		Optional:    true,
 		Default:     nil,
@@ -61,3 +65,19 @@ func validateTeamNameNotChanged(ctx context.Context, diff *schema.ResourceDiff,
 	}
 	return nil
 }
+
+// applyDefaultTeamName falls back to the provider's default_team_name when team_name is not
+// configured. Call it only when creating a resource. The default is recorded in the state like a
+// configured team_name, so validateTeamNameNotChanged treats both the same way - adding the same
+// team_name to the configuration later is a no-op, a different one is rejected.
+func applyDefaultTeamName(d *schema.ResourceData, meta interface{}, teamName **string) {
+	if *teamName != nil && **teamName != "" {
+		return
+	}
+	name := meta.(*client).defaultTeamName
+	if name == "" {
+		return
+	}
+	*teamName = &name
+	_ = d.Set("team_name", name)
+}
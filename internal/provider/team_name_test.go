@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"regexp"
+	"strings"
 	"sync/atomic"
 	"testing"
 
@@ -100,3 +101,108 @@ func TestTeamNameCannotBeChangedAfterCreate(t *testing.T) {
 		},
 	})
 }
+
+// TestDefaultTeamName verifies resources without team_name are created in the provider's
+// default_team_name, and that the default is then treated like a configured team_name.
+func TestDefaultTeamName(t *testing.T) {
+	var data atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Log("Received " + r.Method + " " + r.RequestURI)
+
+		if r.Header.Get("Authorization") != "Bearer foo" {
+			t.Fatal("Not authorized: " + r.Header.Get("Authorization"))
+		}
+
+		prefix := "/api/v1/source-groups"
+		id := "1"
+
+		switch {
+		case r.Method == http.MethodPost && r.RequestURI == prefix:
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !regexp.MustCompile(`"team_name":"Default team"`).Match(body) {
+				t.Errorf("Expected the default team_name in %s", body)
+			}
+			data.Store(body)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"data":{"id":%q,"attributes":%s}}`, id, body)))
+		case r.Method == http.MethodGet && r.RequestURI == prefix+"/"+id:
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"data":{"id":%q,"attributes":%s}}`, id, data.Load().([]byte))))
+		case r.Method == http.MethodDelete && r.RequestURI == prefix+"/"+id:
+			w.WriteHeader(http.StatusNoContent)
+			data.Store([]byte(nil))
+		default:
+			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
+		}
+	}))
+	defer server.Close()
+
+	config := func(defaultTeamName, teamName string) string {
+		teamNameAttr := ""
+		if teamName != "" {
+			teamNameAttr = fmt.Sprintf("team_name = %q", teamName)
+		}
+		return fmt.Sprintf(`
+				provider "logtail" {
+					api_token         = "foo"
+					default_team_name = %q
+				}
+
+				resource "logtail_source_group" "this" {
+					name = "Test Source Group"
+					%s
+				}
+				`, defaultTeamName, teamNameAttr)
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"logtail": func() (*schema.Provider, error) {
+				return New(WithURL(server.URL)), nil
+			},
+		},
+		Steps: []resource.TestStep{
+			// Step 1 - create in the default team.
+			{
+				Config: config("Default team", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("logtail_source_group.this", "team_name", "Default team"),
+				),
+			},
+			// Step 2 - changing the default doesn't affect the existing resource.
+			{
+				Config:   config("Another team", ""),
+				PlanOnly: true,
+			},
+			// Step 3 - spelling out the same team is a no-op.
+			{
+				Config:   config("Default team", "Default team"),
+				PlanOnly: true,
+			},
+			// Step 4 - a different team must fail like for a configured team_name.
+			{
+				Config:      config("Default team", "Second team"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`team_name cannot be changed after resource is created`),
+			},
+		},
+	})
+}
+
+func TestDataSourceTeamNameDescription(t *testing.T) {
+	p := New()
+	for name, r := range p.ResourcesMap {
+		if s, ok := r.Schema["team_name"]; ok && !strings.Contains(s.Description, "default_team_name") {
+			t.Errorf("%s: got team_name description %q, want default_team_name mentioned", name, s.Description)
+		}
+	}
+	// Data sources don't apply default_team_name.
+	for name, r := range p.DataSourcesMap {
+		if s, ok := r.Schema["team_name"]; ok && strings.Contains(s.Description, "default_team_name") {
+			t.Errorf("%s: got team_name description %q, want no default_team_name", name, s.Description)
+		}
+	}
+}
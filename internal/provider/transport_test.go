@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTransportMutualTLS(t *testing.T) {
+	clientCertPEM, clientKeyPEM, clientCert := newTestClientCertificate(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+	serverCAPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	serverCAFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(serverCAFile, []byte(serverCAPEM), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		cfg     providerConfig
+		wantErr string
+	}{
+		{"ca_cert_pem", providerConfig{CACertPEM: serverCAPEM, ClientCertPEM: clientCertPEM, ClientKeyPEM: clientKeyPEM}, ""},
+		{"ca_cert_file", providerConfig{CACertFile: serverCAFile, ClientCertPEM: clientCertPEM, ClientKeyPEM: clientKeyPEM}, ""},
+		{"insecure_skip_verify", providerConfig{InsecureSkipVerify: true, ClientCertPEM: clientCertPEM, ClientKeyPEM: clientKeyPEM}, ""},
+		{"unknown CA", providerConfig{ClientCertPEM: clientCertPEM, ClientKeyPEM: clientKeyPEM}, "certificate"},
+		{"no client certificate", providerConfig{CACertPEM: serverCAPEM}, "certificate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.APIToken, tt.cfg.APITimeout = "foo", 5
+			c, err := (&provider{url: server.URL}).buildClient(tt.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := c.Get(context.Background(), "/")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = resp.Body.Close()
+		})
+	}
+}
+
+func TestTransportProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A proxy receives absolute request URIs.
+		proxied = append(proxied, r.RequestURI)
+		if got, want := r.Header.Get("Proxy-Authorization"), "Basic "+base64.StdEncoding.EncodeToString([]byte("user:secret")); got != want {
+			t.Errorf("got Proxy-Authorization %q, want %q", got, want)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer proxy.Close()
+
+	proxyURL := strings.Replace(proxy.URL, "http://", "http://user:secret@", 1)
+	c, err := (&provider{url: "http://telemetry.example.com"}).buildClient(providerConfig{APIToken: "foo", APITimeout: 5, ProxyURL: proxyURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.Get(context.Background(), "/api/v1/sources")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if len(proxied) != 1 || proxied[0] != "http://telemetry.example.com/api/v1/sources" {
+		t.Errorf("got proxied requests %v", proxied)
+	}
+}
+
+func TestTransportInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     providerConfig
+		wantErr string
+	}{
+		{"ca_cert_pem", providerConfig{CACertPEM: "foo"}, "ca_cert_pem doesn't contain any PEM encoded certificate"},
+		{"ca_cert_file", providerConfig{CACertFile: filepath.Join(t.TempDir(), "missing.pem")}, "reading ca_cert_file"},
+		{"client_key_pem", providerConfig{ClientCertPEM: "foo"}, "client_cert_pem and client_key_pem must be set together"},
+		{"client_cert_pem", providerConfig{ClientCertPEM: "foo", ClientKeyPEM: "bar"}, "invalid client_cert_pem or client_key_pem"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTransport(tt.cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// newTestClientCertificate returns a self-signed client certificate and its key.
+func newTestClientCertificate(t *testing.T) (certPEM, keyPEM string, cert *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform-provider-logtail test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err = x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM, cert
+}
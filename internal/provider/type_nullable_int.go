@@ -1,8 +1,7 @@
 package provider
 
 import (
-	"encoding/json"
-
+	"github.com/betterstackhq/terraform-provider-logtail/betterstack"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -11,36 +10,7 @@ import (
 // sentinel such as -1, since HCL cannot distinguish null from an unset attribute.
 // Ported from terraform-provider-better-uptime, where it backs ssl_expiration and
 // domain_expiration.
-//
-//   - Unset: the field is omitted from JSON (nil *NullableInt with omitempty)
-//   - Set to the sentinel: the field is sent as JSON null (ExplicitNull)
-//   - Set to a value: the field is sent as that value
-type NullableInt struct {
-	Value        *int
-	ExplicitNull bool
-}
-
-func (n NullableInt) MarshalJSON() ([]byte, error) {
-	if n.ExplicitNull {
-		return []byte("null"), nil
-	}
-	return json.Marshal(n.Value)
-}
-
-func (n *NullableInt) UnmarshalJSON(data []byte) error {
-	if string(data) == "null" {
-		n.Value = nil
-		n.ExplicitNull = true
-		return nil
-	}
-	var v int
-	if err := json.Unmarshal(data, &v); err != nil {
-		return err
-	}
-	n.Value = &v
-	n.ExplicitNull = false
-	return nil
-}
+type NullableInt = betterstack.NullableInt
 
 // NullableIntFromResourceData loads a nullable int field from the configuration:
 // nil when the field is not set in config (omit from the request), ExplicitNull when
@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// Plan-time VRL validation. This is not a full VRL parser - function names, types and fallibility
+// are still only checked by Better Stack on apply. It catches the syntax errors that are easy to
+// make in HCL heredocs: unbalanced braces, unterminated string literals, stray characters and
+// statements that can't start an expression. Anything it accepts is left to the server, so a valid
+// program is never rejected. Escape sequences are left to the server too, programs in the wild rely
+// on e.g. "\d" in regular expressions passed as strings.
+
+type vrlTokenKind int
+
+const (
+	vrlTokenIdent vrlTokenKind = iota
+	vrlTokenLiteral
+	vrlTokenOperator
+	vrlTokenOpen
+	vrlTokenClose
+	vrlTokenSeparator // newline or ;
+	vrlTokenPunct     // , and :
+)
+
+type vrlToken struct {
+	kind   vrlTokenKind
+	text   string
+	line   int
+	column int
+}
+
+// syntaxError is a syntax error at a 1-based line and column (counted in characters) of a VRL
+// program or SQL query.
+type syntaxError struct {
+	line    int
+	column  int
+	message string
+}
+
+func (e *syntaxError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.line, e.column, e.message)
+}
+
+// Operators, longest first so that e.g. `==` isn't lexed as two `=`.
+var vrlOperators = []string{
+	"==", "!=", "<=", ">=", "&&", "||", "??", "|=", "->",
+	"=", "<", ">", "+", "-", "*", "/", "%", "!", "|", "?",
+}
+
+// textScanner tracks the line and column while scanning VRL programs and SQL queries.
+type textScanner struct {
+	src    []rune
+	pos    int
+	line   int
+	column int
+	tokens []vrlToken
+}
+
+func (l *textScanner) peek(offset int) rune {
+	if l.pos+offset < len(l.src) {
+		return l.src[l.pos+offset]
+	}
+	return 0
+}
+
+func (l *textScanner) advance() rune {
+	r := l.src[l.pos]
+	l.pos++
+	if r == '\n' {
+		l.line++
+		l.column = 1
+	} else {
+		l.column++
+	}
+	return r
+}
+
+func (l *textScanner) errorf(line, column int, format string, args ...interface{}) error {
+	return &syntaxError{line: line, column: column, message: fmt.Sprintf(format, args...)}
+}
+
+func lexVRL(program string) ([]vrlToken, error) {
+	l := &textScanner{src: []rune(program), line: 1, column: 1}
+	for l.pos < len(l.src) {
+		r := l.peek(0)
+		line, column := l.line, l.column
+		emit := func(kind vrlTokenKind, text string) {
+			l.tokens = append(l.tokens, vrlToken{kind: kind, text: text, line: line, column: column})
+		}
+
+		switch {
+		case r == '\n' || r == ';':
+			l.advance()
+			emit(vrlTokenSeparator, string(r))
+		case unicode.IsSpace(r):
+			l.advance()
+		case r == '#':
+			for l.pos < len(l.src) && l.peek(0) != '\n' {
+				l.advance()
+			}
+		case r == '"':
+			if err := l.vrlString(); err != nil {
+				return nil, err
+			}
+			emit(vrlTokenLiteral, "string")
+		case (r == 's' || r == 'r' || r == 't') && l.peek(1) == '\'':
+			if err := l.vrlRawString(); err != nil {
+				return nil, err
+			}
+			emit(vrlTokenLiteral, "string")
+		case unicode.IsDigit(r):
+			start := l.pos
+			for l.pos < len(l.src) && (unicode.IsDigit(l.peek(0)) || l.peek(0) == '_' || l.peek(0) == '.' && unicode.IsDigit(l.peek(1))) {
+				l.advance()
+			}
+			emit(vrlTokenLiteral, string(l.src[start:l.pos]))
+		case r == '_' || unicode.IsLetter(r):
+			start := l.pos
+			for l.pos < len(l.src) && (l.peek(0) == '_' || unicode.IsLetter(l.peek(0)) || unicode.IsDigit(l.peek(0))) {
+				l.advance()
+			}
+			emit(vrlTokenIdent, string(l.src[start:l.pos]))
+		case r == '.':
+			// A path (`.`, `.field`, `."quoted field"`), the segments are lexed as separate tokens.
+			l.advance()
+			emit(vrlTokenIdent, ".")
+		case strings.ContainsRune("([{", r):
+			l.advance()
+			emit(vrlTokenOpen, string(r))
+		case strings.ContainsRune(")]}", r):
+			l.advance()
+			emit(vrlTokenClose, string(r))
+		case r == ',' || r == ':':
+			l.advance()
+			emit(vrlTokenPunct, string(r))
+		default:
+			op := ""
+			for _, candidate := range vrlOperators {
+				if strings.HasPrefix(string(l.src[l.pos:min(l.pos+len(candidate), len(l.src))]), candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, l.errorf(line, column, "unexpected character %q", r)
+			}
+			for range op {
+				l.advance()
+			}
+			emit(vrlTokenOperator, op)
+		}
+	}
+	return l.tokens, nil
+}
+
+func (l *textScanner) vrlString() error {
+	line, column := l.line, l.column
+	l.advance() // "
+	for l.pos < len(l.src) {
+		switch l.advance() {
+		case '"':
+			return nil
+		case '\\':
+			if l.pos < len(l.src) {
+				l.advance()
+			}
+		}
+	}
+	return l.errorf(line, column, "unterminated string literal")
+}
+
+func (l *textScanner) vrlRawString() error {
+	line, column := l.line, l.column
+	prefix := l.advance()
+	l.advance() // '
+	for l.pos < len(l.src) {
+		switch l.advance() {
+		case '\'':
+			return nil
+		case '\\':
+			if l.pos < len(l.src) {
+				l.advance()
+			}
+		}
+	}
+	return l.errorf(line, column, "unterminated %c'...' literal", prefix)
+}
+
+// vrlStatementOperators can't start a statement. Operators that may continue an expression from
+// the previous line (e.g. `&&`, `+`) aren't listed, neither are unary `!` and `-`.
+var vrlStatementOperators = map[string]bool{"=": true, "|=": true, "->": true, "?": true, "==": true, "!=": true}
+
+// vrlTrailingOperators can't end a program.
+var vrlTrailingOperators = map[string]bool{
+	"==": true, "!=": true, "<=": true, ">=": true, "&&": true, "||": true, "??": true, "|=": true, "->": true,
+	"=": true, "<": true, ">": true, "+": true, "-": true, "*": true, "/": true, "|": true,
+}
+
+var vrlClosers = map[string]string{"(": ")", "[": "]", "{": "}"}
+
+// checkVRL returns a *syntaxError for the first syntax error found in program.
+func checkVRL(program string) error {
+	tokens, err := lexVRL(program)
+	if err != nil {
+		return err
+	}
+
+	var open []vrlToken
+	statementStart := true
+	var last *vrlToken
+	for i := range tokens {
+		t := tokens[i]
+		// Newlines only separate statements in blocks and at the top level, not within () or [].
+		inBlock := len(open) == 0 || open[len(open)-1].text == "{"
+
+		switch t.kind {
+		case vrlTokenSeparator:
+			if inBlock {
+				statementStart = true
+			}
+			continue
+		case vrlTokenOpen:
+			open = append(open, t)
+		case vrlTokenClose:
+			if len(open) == 0 {
+				return &syntaxError{line: t.line, column: t.column, message: fmt.Sprintf("unexpected %q without a matching opening bracket", t.text)}
+			}
+			opener := open[len(open)-1]
+			if vrlClosers[opener.text] != t.text {
+				return &syntaxError{line: t.line, column: t.column, message: fmt.Sprintf("unexpected %q, expected %q to close %q opened at line %d, column %d", t.text, vrlClosers[opener.text], opener.text, opener.line, opener.column)}
+			}
+			open = open[:len(open)-1]
+		case vrlTokenOperator:
+			if statementStart && inBlock && vrlStatementOperators[t.text] {
+				return &syntaxError{line: t.line, column: t.column, message: fmt.Sprintf("unexpected %q at the start of a statement", t.text)}
+			}
+		case vrlTokenPunct:
+			if statementStart && len(open) == 0 {
+				return &syntaxError{line: t.line, column: t.column, message: fmt.Sprintf("unexpected %q at the start of a statement", t.text)}
+			}
+		case vrlTokenIdent:
+			if t.text == "else" && (last == nil || last.text != "}") {
+				return &syntaxError{line: t.line, column: t.column, message: "unexpected \"else\" without a preceding if block"}
+			}
+		}
+		statementStart = t.kind == vrlTokenOpen && t.text == "{"
+		last = &tokens[i]
+	}
+
+	if len(open) > 0 {
+		opener := open[len(open)-1]
+		return &syntaxError{line: opener.line, column: opener.column, message: fmt.Sprintf("unclosed %q, expected %q before the end of the program", opener.text, vrlClosers[opener.text])}
+	}
+	if last != nil && last.kind == vrlTokenOperator && vrlTrailingOperators[last.text] {
+		return &syntaxError{line: last.line, column: last.column, message: fmt.Sprintf("unexpected end of program after %q", last.text)}
+	}
+	return nil
+}
+
+// validateVRL is the ValidateDiagFunc of VRL program attributes, reporting syntax errors found by
+// checkVRL during `terraform validate`.
+func validateVRL(i interface{}, path cty.Path) diag.Diagnostics {
+	program, ok := i.(string)
+	if !ok {
+		return nil
+	}
+	if err := checkVRL(program); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid VRL program",
+			Detail:        fmt.Sprintf("Syntax error at %s.", err),
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestCheckVRL(t *testing.T) {
+	valid := []string{
+		``,
+		`.message = "hello"`,
+		`del(.password)`,
+		"# comment with { unbalanced braces\n.level = upcase!(.level)",
+		`. = parse_json!(string!(.message))`,
+		`.message = replace(string!(.message), r'\d{4}-\d{4}', "****")`,
+		`.ts = t'2021-02-11T10:32:50.553955473Z'; .s = s'raw \ string'`,
+		`.tags = { "env": "production", "team": [1, 2.5, 1_000] }`,
+		`."quoted field" = .a ?? .b`,
+		`.escaped = "quote \" brace \{ newline \n"`,
+		`.duration_ms = extract(.message, "in (\d+(?:\.\d+)?)ms")`,
+		"if .status >= 500 {\n  .level = \"error\"\n} else if exists(.warning) {\n  .level = \"warn\"\n} else {\n  abort\n}",
+		".x = .a &&\n  .b",
+		"if .a\n  || .b {\n  .c = 1\n}",
+		"%custom = 1\n.n = .n % 2",
+		".tags = map_values(object!(.tags)) -> |value| { downcase!(value) }",
+		"for_each(object!(.)) -> |key, value| {\n  .flat = push(array!(.flat), key)\n}",
+		".x, err = parse_int(.y)",
+		"parsed = parse_key_value!(\n  .message,\n  field_delimiter: \",\"\n)",
+		".x |= { \"a\": !false, \"b\": -1 }",
+	}
+	for _, program := range valid {
+		if err := checkVRL(program); err != nil {
+			t.Errorf("checkVRL(%q): unexpected error %v", program, err)
+		}
+	}
+
+	invalid := []struct {
+		program string
+		want    string
+	}{
+		{"if .a {\n  .b = 1\n", "line 1, column 7: unclosed \"{\""},
+		{".a = [1, 2)", "line 1, column 11: unexpected \")\", expected \"]\" to close \"[\" opened at line 1, column 6"},
+		{".a = 1\n}", "line 2, column 1: unexpected \"}\" without a matching opening bracket"},
+		{".message = \"hello", "line 1, column 12: unterminated string literal"},
+		{".message = r'abc", "line 1, column 12: unterminated r'...' literal"},
+		{".a = 1\n.b = @c", "line 2, column 6: unexpected character '@'"},
+		{".a = $b", "line 1, column 6: unexpected character '$'"},
+		{".a = 1\n= 2", "line 2, column 1: unexpected \"=\" at the start of a statement"},
+		{".a = 1\n, .b", "line 2, column 1: unexpected \",\" at the start of a statement"},
+		{".a = 1\nelse { .b = 2 }", "line 2, column 1: unexpected \"else\" without a preceding if block"},
+		{".a = 1\n.b =", "line 2, column 4: unexpected end of program after \"=\""},
+		{"# ünïcödé\n.a = \"ü\" @", "line 2, column 10: unexpected character '@'"},
+	}
+	for _, tt := range invalid {
+		err := checkVRL(tt.program)
+		if err == nil {
+			t.Errorf("checkVRL(%q): expected an error", tt.program)
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("checkVRL(%q): got %q, want prefix %q", tt.program, err.Error(), tt.want)
+		}
+	}
+}
+
+func TestValidateVRL(t *testing.T) {
+	path := cty.GetAttrPath("configuration").IndexInt(0).GetAttr("vrl_transformation")
+	diags := validateVRL("if .a {", path)
+	if len(diags) != 1 {
+		t.Fatalf("got %d diagnostics, want 1: %v", len(diags), diags)
+	}
+	if !diags[0].AttributePath.Equals(path) {
+		t.Errorf("got path %#v, want %#v", diags[0].AttributePath, path)
+	}
+	if want := "Syntax error at line 1, column 7"; !strings.HasPrefix(diags[0].Detail, want) {
+		t.Errorf("got detail %q, want prefix %q", diags[0].Detail, want)
+	}
+
+	if diags := validateVRL(".a = 1", path); len(diags) != 0 {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
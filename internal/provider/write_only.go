@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Write-only attributes (Terraform 1.11+) let secrets reach the API without ever being persisted
+// to the plan or state. Each updatable secret `x` gets an `x_wo` attribute and an `x_wo_version`
+// counter: Terraform never diffs write-only values, so bumping the version is what triggers
+// sending a rotated secret. Create-only secrets (custom_bucket) need no version.
+const writeOnlyVersionSuffix = "_version"
+
+// writeOnlyStringFromResourceData returns the value of a write-only attribute at path, or nil if
+// it is not configured (including when a parent block is absent). Write-only values are null in
+// the plan and state, so they can only be read from the raw config during apply.
+func writeOnlyStringFromResourceData(d *schema.ResourceData, path cty.Path) *string {
+	val, diags := d.GetRawConfigAt(path)
+	if diags.HasError() || val.IsNull() || !val.IsKnown() || !val.Type().Equals(cty.String) {
+		return nil
+	}
+	v := val.AsString()
+	return &v
+}
+
+// writeOnlyConfigured reports whether the write-only attribute at path is set in the raw config
+// of a plan, e.g. to validate it in CustomizeDiff where the planned value is always null.
+func writeOnlyConfigured(diff *schema.ResourceDiff, path cty.Path) bool {
+	configured := false
+	_ = cty.Walk(diff.GetRawConfig(), func(p cty.Path, v cty.Value) (bool, error) {
+		if p.Equals(path) {
+			configured = !v.IsNull()
+			return false, nil
+		}
+		return true, nil
+	})
+	return configured
+}
+
+// withoutWriteOnly returns a copy of a resource schema without its write-only attributes and
+// their version counters, recursing into nested blocks. Data sources built from resource schemas
+// use it - write-only attributes are only valid in managed resources. Cross-attribute constraints
+// are dropped as well, they may refer to the removed attributes and are meaningless for the
+// computed attributes of a data source.
+func withoutWriteOnly(in map[string]*schema.Schema) map[string]*schema.Schema {
+	out := make(map[string]*schema.Schema, len(in))
+	for k, v := range in {
+		if v.WriteOnly {
+			continue
+		}
+		if base := strings.TrimSuffix(k, writeOnlyVersionSuffix); base != k {
+			if wo, ok := in[base]; ok && wo.WriteOnly {
+				continue
+			}
+		}
+		cp := *v
+		cp.ConflictsWith = nil
+		cp.ExactlyOneOf = nil
+		cp.AtLeastOneOf = nil
+		cp.RequiredWith = nil
+		if elem, ok := v.Elem.(*schema.Resource); ok {
+			cp.Elem = &schema.Resource{Schema: withoutWriteOnly(elem.Schema)}
+		}
+		out[k] = &cp
+	}
+	return out
+}
@@ -1,15 +1,14 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
-	"io"
-	"log"
 	"os"
+	"strings"
 
 	"github.com/betterstackhq/terraform-provider-logtail/internal/provider"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 )
 
 // Format Terraform examples/.
@@ -22,6 +21,14 @@ import (
 var version string
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		if err := generate(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	var printVersionAndExit bool
 	var debugMode bool
 
@@ -34,20 +41,54 @@ func main() {
 		return
 	}
 
-	// Do not include a timestamp (terraform includes it already).
-	log.SetFlags(log.Lshortfile)
-	// Log request/response bodies (that may contain sensitive data) if (and only if)
-	// TF_PROVIDER_LOGTAIL_LOG_INSECURE env var is set to 1.
-	if os.Getenv("TF_PROVIDER_LOGTAIL_LOG_INSECURE") != "1" {
-		log.SetOutput(io.Discard)
+	ctx := context.Background()
+
+	// SDKv2 and terraform-plugin-framework resources are served side by side by a mux server.
+	muxServer, err := provider.NewMuxServer(ctx, provider.WithVersion(version))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	var serveOpts []tf6server.ServeOpt
+	if debugMode {
+		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
+	}
+
+	err = tf6server.Serve("registry.terraform.io/BetterStackHQ/logtail", muxServer, serveOpts...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// generate implements `terraform-provider-logtail generate`, exporting the resources of an existing
+// Better Stack team to Terraform configuration with import blocks. The provider is configured from
+// its environment variables, e.g. LOGTAIL_API_TOKEN.
+func generate(args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	out := flags.String("out", ".", "directory to write the generated .tf files to, existing files are never overwritten")
+	resources := flags.String("resources", "", "comma-separated resource types to export (default all: "+strings.Join(provider.GenerateResourceTypes, ", ")+")")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: LOGTAIL_API_TOKEN=... terraform-provider-logtail generate [-out dir] [-resources types]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
 	}
 
-	opts := &plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return provider.New(provider.WithVersion(version))
-		},
-		Debug: debugMode,
+	var types []string
+	for _, t := range strings.Split(*resources, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
 	}
 
-	plugin.Serve(opts)
+	return provider.Generate(context.Background(), provider.GenerateOptions{
+		OutputDir:     *out,
+		ResourceTypes: types,
+		Log:           os.Stderr,
+	}, provider.WithVersion(version))
 }